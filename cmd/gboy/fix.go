@@ -0,0 +1,110 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/gorkaio/gboy/pkg/cart"
+)
+
+// fixOptions selects the header changes made by the fix subcommand
+type fixOptions struct {
+	title    string
+	cgbFlag  byte
+	sgb      bool
+	logo     bool
+	pad      bool
+	padValue byte
+}
+
+// fix repairs the header of every ROM given in args in place. It returns false if any of them failed.
+func fix(args []string, stdout, stderr io.Writer) bool {
+	flags := flag.NewFlagSet("fix", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	title := flags.String("title", "", "set the title")
+	cgb := flags.Bool("cgb", false, "flag the ROM as CGB compatible")
+	cgbOnly := flags.Bool("cgb-only", false, "flag the ROM as CGB only")
+	sgb := flags.Bool("sgb", false, "enable SGB functions")
+	logo := flags.Bool("logo", false, "write the Nintendo logo")
+	pad := flags.Bool("pad", false, "pad the ROM to a valid size")
+	padValue := flags.Uint("pad-value", 0xFF, "byte to pad the ROM with")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: gboy fix [options] <rom...>")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return false
+	}
+	if flags.NArg() == 0 || *cgb && *cgbOnly || *padValue > 0xFF {
+		flags.Usage()
+		return false
+	}
+
+	options := fixOptions{title: *title, sgb: *sgb, logo: *logo, pad: *pad, padValue: byte(*padValue)}
+	if *cgb {
+		options.cgbFlag = cart.CGBSupported
+	}
+	if *cgbOnly {
+		options.cgbFlag = cart.CGBOnly
+	}
+
+	ok := true
+	for _, romfile := range flags.Args() {
+		if err := fixROM(romfile, options); err != nil {
+			fmt.Fprintf(stderr, "%s: %s\n", romfile, err.Error())
+			ok = false
+			continue
+		}
+		fmt.Fprintf(stdout, "%s: fixed\n", romfile)
+	}
+	return ok
+}
+
+// fixROM applies the changes to a ROM file and writes it back only if they all succeed.
+// Archives are rejected, as the fixed image would replace the whole archive.
+func fixROM(romfile string, options fixOptions) error {
+	data, err := ioutil.ReadFile(romfile)
+	if err != nil {
+		return err
+	}
+	if cart.IsArchive(data) {
+		return fmt.Errorf("Cannot fix ROMs in zip or gzip archives, unpack them first")
+	}
+	data, err = options.apply(data)
+	if err != nil {
+		return err
+	}
+	return cart.WriteFile(romfile, data)
+}
+
+func (options fixOptions) apply(data []byte) ([]byte, error) {
+	var err error
+	if options.cgbFlag != 0 {
+		if err = cart.SetCGBFlag(data, options.cgbFlag); err != nil {
+			return nil, err
+		}
+	}
+	if options.sgb {
+		if err = cart.SetSGB(data); err != nil {
+			return nil, err
+		}
+	}
+	if options.logo {
+		if err = cart.SetLogo(data); err != nil {
+			return nil, err
+		}
+	}
+	if options.title != "" {
+		if err = cart.SetTitle(data, options.title); err != nil {
+			return nil, err
+		}
+	}
+	if options.pad {
+		if data, err = cart.Pad(data, options.padValue); err != nil {
+			return nil, err
+		}
+	}
+	return data, cart.FixChecksums(data)
+}
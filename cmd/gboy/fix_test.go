@@ -0,0 +1,139 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gorkaio/gboy/pkg/cart"
+	"github.com/stretchr/testify/assert"
+)
+
+// copyTestROM copies the test ROM to a temporary directory, applying the given changes
+func copyTestROM(t *testing.T, change func(data []byte) []byte) (string, string) {
+	data, err := ioutil.ReadFile(testrom)
+	assert.NoError(t, err)
+	dir, err := ioutil.TempDir("", "gboy")
+	assert.NoError(t, err)
+	romfile := filepath.Join(dir, "homebrew.gb")
+	assert.NoError(t, ioutil.WriteFile(romfile, change(data), 0644))
+	return dir, romfile
+}
+
+func TestFixRepairsChecksums(t *testing.T) {
+	dir, romfile := copyTestROM(t, func(data []byte) []byte {
+		data[0x14D] = 0x00
+		data[0x14E] = 0x00
+		return data
+	})
+	defer os.RemoveAll(dir)
+
+	var stdout, stderr bytes.Buffer
+	assert.True(t, fix([]string{romfile}, &stdout, &stderr))
+	assert.Empty(t, stderr.String())
+
+	data, err := ioutil.ReadFile(romfile)
+	assert.NoError(t, err)
+	original, err := ioutil.ReadFile(testrom)
+	assert.NoError(t, err)
+	assert.Equal(t, original, data)
+}
+
+func TestFixSetsHeaderFields(t *testing.T) {
+	dir, romfile := copyTestROM(t, func(data []byte) []byte {
+		return data[:0x6000]
+	})
+	defer os.RemoveAll(dir)
+
+	var stdout, stderr bytes.Buffer
+	assert.True(t, fix([]string{"--title", "HOMEBREW", "--cgb", "--sgb", "--pad", "--pad-value", "0", romfile}, &stdout, &stderr))
+	assert.Empty(t, stderr.String())
+
+	data, err := ioutil.ReadFile(romfile)
+	assert.NoError(t, err)
+	assert.Len(t, data, 0x8000)
+	header, err := cart.ParseHeader(data)
+	assert.NoError(t, err)
+	assert.Equal(t, "HOMEBREW", header.Title)
+	assert.True(t, header.CGB())
+	assert.False(t, header.CGBOnly())
+	assert.True(t, header.SGB)
+	assert.NoError(t, header.Verify())
+}
+
+func TestFixLeavesROMUntouchedOnError(t *testing.T) {
+	dir, romfile := copyTestROM(t, func(data []byte) []byte {
+		return data
+	})
+	defer os.RemoveAll(dir)
+
+	var stdout, stderr bytes.Buffer
+	assert.False(t, fix([]string{"--title", "A TITLE LONGER THAN SIXTEEN", romfile}, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "longer than 16 characters")
+
+	data, err := ioutil.ReadFile(romfile)
+	assert.NoError(t, err)
+	original, err := ioutil.ReadFile(testrom)
+	assert.NoError(t, err)
+	assert.Equal(t, original, data)
+}
+
+func TestFixKeepsTheROMFileMode(t *testing.T) {
+	dir, romfile := copyTestROM(t, func(data []byte) []byte {
+		data[0x14D] = 0x00
+		return data
+	})
+	defer os.RemoveAll(dir)
+	assert.NoError(t, os.Chmod(romfile, 0600))
+
+	var stdout, stderr bytes.Buffer
+	assert.True(t, fix([]string{romfile}, &stdout, &stderr))
+	info, err := os.Stat(romfile)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestFixRejectsArchives(t *testing.T) {
+	rom, err := ioutil.ReadFile(testrom)
+	assert.NoError(t, err)
+
+	var gz bytes.Buffer
+	gw := gzip.NewWriter(&gz)
+	_, err = gw.Write(rom)
+	assert.NoError(t, err)
+	assert.NoError(t, gw.Close())
+
+	var zipped bytes.Buffer
+	zw := zip.NewWriter(&zipped)
+	f, err := zw.Create("homebrew.gb")
+	assert.NoError(t, err)
+	_, err = f.Write(rom)
+	assert.NoError(t, err)
+	assert.NoError(t, zw.Close())
+
+	archives := map[string][]byte{"homebrew.gb.gz": gz.Bytes(), "homebrew.zip": zipped.Bytes()}
+	for name, archive := range archives {
+		dir, err := ioutil.TempDir("", "gboy")
+		assert.NoError(t, err)
+		romfile := filepath.Join(dir, name)
+		assert.NoError(t, ioutil.WriteFile(romfile, archive, 0644))
+
+		var stdout, stderr bytes.Buffer
+		assert.False(t, fix([]string{romfile}, &stdout, &stderr), name)
+		assert.Contains(t, stderr.String(), "Cannot fix ROMs in zip or gzip archives", name)
+		data, err := ioutil.ReadFile(romfile)
+		assert.NoError(t, err)
+		assert.Equal(t, archive, data, "%s is left untouched", name)
+		os.RemoveAll(dir)
+	}
+}
+
+func TestFixRejectsConflictingOptions(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	assert.False(t, fix([]string{"--cgb", "--cgb-only", testrom}, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "Usage: gboy fix")
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/gorkaio/gboy/pkg/cart"
+	"github.com/olekukonko/tablewriter"
+)
+
+// romInfo is the header summary printed by the info subcommand
+type romInfo struct {
+	File                string `json:"file"`
+	Error               string `json:"error,omitempty"`
+	Title               string `json:"title,omitempty"`
+	Manufacturer        string `json:"manufacturer,omitempty"`
+	TypeID              byte   `json:"typeId"`
+	Type                string `json:"type,omitempty"`
+	MBC                 string `json:"mbc,omitempty"`
+	ROMSize             int    `json:"romSize"`
+	RAMSize             int    `json:"ramSize"`
+	CGB                 string `json:"cgb,omitempty"`
+	SGB                 bool   `json:"sgb"`
+	Licensee            string `json:"licensee,omitempty"`
+	Version             byte   `json:"version"`
+	LogoValid           bool   `json:"logoValid"`
+	HeaderChecksumValid bool   `json:"headerChecksumValid"`
+	GlobalChecksumValid bool   `json:"globalChecksumValid"`
+}
+
+// info prints the header of every ROM given in args. It returns false if any of them could not be read.
+func info(args []string, stdout, stderr io.Writer) bool {
+	flags := flag.NewFlagSet("info", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	asJSON := flags.Bool("json", false, "print headers as JSON")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: gboy info [--json] <rom...>")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return false
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return false
+	}
+
+	ok := true
+	infos := []romInfo{}
+	for _, romfile := range flags.Args() {
+		info := readROMInfo(romfile)
+		if info.Error != "" {
+			ok = false
+		}
+		infos = append(infos, info)
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(infos); err != nil {
+			fmt.Fprintln(stderr, err.Error())
+			return false
+		}
+		return ok
+	}
+
+	for _, info := range infos {
+		printROMInfo(stdout, info)
+	}
+	return ok
+}
+
+func readROMInfo(romfile string) romInfo {
+	info := romInfo{File: romfile}
+	data, err := cart.FromFile(romfile).ReadROM()
+	if err != nil {
+		info.Error = err.Error()
+		return info
+	}
+	header, err := cart.ParseHeader(data)
+	if err != nil {
+		info.Error = err.Error()
+		return info
+	}
+
+	info.Title = header.Title
+	info.Manufacturer = header.Manufacturer
+	info.TypeID = header.Type.ID
+	info.Type = header.Type.Description
+	info.MBC = header.Type.Name
+	info.ROMSize = header.ROMSize
+	info.RAMSize = header.RAMSize
+	info.CGB = cgbSupport(header)
+	info.SGB = header.SGB
+	info.Licensee = header.Licensee
+	info.Version = header.Version
+	info.LogoValid = header.LogoValid()
+	info.HeaderChecksumValid = header.HeaderChecksumValid()
+	info.GlobalChecksumValid = header.GlobalChecksumValid()
+	return info
+}
+
+func cgbSupport(header *cart.Header) string {
+	switch {
+	case header.CGBOnly():
+		return "only"
+	case header.CGB():
+		return "supported"
+	}
+	return "none"
+}
+
+func printROMInfo(out io.Writer, info romInfo) {
+	table := tablewriter.NewWriter(out)
+	table.SetHeader([]string{"File", info.File})
+	table.SetAutoFormatHeaders(false)
+	table.SetAutoWrapText(false)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	if info.Error != "" {
+		table.Append([]string{"Error", info.Error})
+		table.Render()
+		return
+	}
+	table.Append([]string{"Title", info.Title})
+	if info.Manufacturer != "" {
+		table.Append([]string{"Manufacturer", info.Manufacturer})
+	}
+	table.Append([]string{"Type", fmt.Sprintf("%s (%#02x)", info.Type, info.TypeID)})
+	table.Append([]string{"MBC", info.MBC})
+	table.Append([]string{"ROM size", fmt.Sprintf("%d KiB", info.ROMSize/1024)})
+	table.Append([]string{"RAM size", fmt.Sprintf("%d KiB", info.RAMSize/1024)})
+	table.Append([]string{"CGB", info.CGB})
+	table.Append([]string{"SGB", yesNo(info.SGB)})
+	table.Append([]string{"Licensee", info.Licensee})
+	table.Append([]string{"Version", fmt.Sprintf("%d", info.Version)})
+	table.Append([]string{"Nintendo logo", validity(info.LogoValid)})
+	table.Append([]string{"Header checksum", validity(info.HeaderChecksumValid)})
+	table.Append([]string{"Global checksum", validity(info.GlobalChecksumValid)})
+	table.Render()
+}
+
+func yesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "no"
+}
+
+func validity(valid bool) string {
+	if valid {
+		return "ok"
+	}
+	return "invalid"
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testrom = "../../roms/10-print.gb"
+
+func TestInfoPrintsHeader(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	assert.True(t, info([]string{testrom}, &stdout, &stderr))
+	assert.Contains(t, stdout.String(), "10 PRINT")
+	assert.Contains(t, stdout.String(), "ROM only (0x00)")
+	assert.Contains(t, stdout.String(), "32 KiB")
+	assert.Empty(t, stderr.String())
+}
+
+func TestInfoPrintsJSON(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	assert.False(t, info([]string{"--json", testrom, "missing.gb"}, &stdout, &stderr))
+
+	infos := []romInfo{}
+	assert.NoError(t, json.Unmarshal(stdout.Bytes(), &infos))
+	assert.Equal(t, []romInfo{
+		{
+			File:                testrom,
+			Title:               "10 PRINT",
+			TypeID:              0x00,
+			Type:                "ROM only",
+			MBC:                 "MBC0",
+			ROMSize:             0x8000,
+			RAMSize:             0,
+			CGB:                 "none",
+			SGB:                 false,
+			Licensee:            "None",
+			Version:             2,
+			LogoValid:           true,
+			HeaderChecksumValid: true,
+			GlobalChecksumValid: true,
+		},
+		{
+			File:  "missing.gb",
+			Error: "open missing.gb: no such file or directory",
+		},
+	}, infos)
+}
+
+func TestInfoNeedsROMs(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	assert.False(t, info([]string{"--json"}, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "Usage: gboy info")
+}
@@ -1,36 +1,78 @@
 package main
 
 import (
+	"flag"
 	"fmt"
-	"github.com/gorkaio/gboy/pkg/gameboy"
 	"github.com/gorkaio/gboy/pkg/cpu"
+	"github.com/gorkaio/gboy/pkg/gameboy"
 	"github.com/gorkaio/gboy/pkg/memory"
+	"github.com/gorkaio/gboy/pkg/ppu"
 	"os"
 )
 
 func main() {
 	if len(os.Args) < 2 {
 		fmt.Println("No ROM file specified!")
+		fmt.Println("Usage: gboy [--patch <patch>] [--fifo] <rom> | gboy info [--json] <rom...> | gboy fix [options] <rom...>")
 		os.Exit(1)
 	}
 
-	romfile := os.Args[1]
+	switch os.Args[1] {
+	case "info":
+		if !info(os.Args[2:], os.Stdout, os.Stderr) {
+			os.Exit(1)
+		}
+		return
+	case "fix":
+		if !fix(os.Args[2:], os.Stdout, os.Stderr) {
+			os.Exit(1)
+		}
+		return
+	}
+
+	run(os.Args[1:])
+}
+
+func run(args []string) {
+	flags := flag.NewFlagSet("gboy", flag.ExitOnError)
+	patchfile := flags.String("patch", "", "IPS, UPS or BPS patch to apply, instead of the one named like the ROM")
+	fifo := flags.Bool("fifo", false, "Draw with the pixel FIFO renderer, slower but accurate to the dot")
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		fmt.Println("Usage: gboy [--patch <patch>] [--fifo] <rom>")
+		os.Exit(1)
+	}
+	romfile := flags.Arg(0)
+	patchfiles := []string{}
+	if *patchfile != "" {
+		patchfiles = append(patchfiles, *patchfile)
+	}
+
 	fmt.Println("GBoy!")
 	fmt.Printf("Loading %q...\n", romfile)
 
 	m := memory.New()
 	c := cpu.New(m)
-	gb, err := gameboy.New(m, c)
+	renderer := ppu.ScanlineRenderer
+	if *fifo {
+		renderer = ppu.FIFORenderer
+	}
+	gb, err := gameboy.New(m, c, renderer)
 	if err != nil {
 		fmt.Println(err.Error())
 		os.Exit(1)
 	}
 
-	err = gb.LoadCart(romfile)
+	err = gb.LoadCart(romfile, patchfiles...)
 	if err != nil {
 		fmt.Println(err.Error())
 		os.Exit(1)
 	}
 
-	gb.Run()
+	err = gb.Run()
+	if err != nil {
+		fmt.Println(err.Error())
+		gb.Eject()
+		os.Exit(1)
+	}
 }
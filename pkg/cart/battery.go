@@ -0,0 +1,25 @@
+package cart
+
+import "fmt"
+
+// Battery is implemented by controllers whose state survives power off in a .sav file
+type Battery interface {
+	SaveData() []byte
+	LoadSaveData(data []byte) error
+}
+
+// saveRAM returns a copy of the external RAM to be written to a .sav file
+func saveRAM(ram []byte) []byte {
+	data := make([]byte, len(ram))
+	copy(data, ram)
+	return data
+}
+
+// loadRAM restores the external RAM from a .sav file
+func loadRAM(ram []byte, data []byte) error {
+	if len(data) != len(ram) {
+		return fmt.Errorf("Save data is %d bytes long, expected %d bytes of RAM", len(data), len(ram))
+	}
+	copy(ram, data)
+	return nil
+}
@@ -0,0 +1,78 @@
+package cart_test
+
+import (
+	"testing"
+
+	"github.com/gorkaio/gboy/pkg/cart"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestROMRAMCartsMapRAM(t *testing.T) {
+	tests := []struct {
+		id          byte
+		description string
+		battery     bool
+	}{
+		{0x08, "ROM+RAM", false},
+		{0x09, "ROM+RAM+BATTERY", true},
+	}
+
+	for _, test := range tests {
+		c, err := cart.NewCart(buildROM(2, test.id, 0x02))
+		assert.NoError(t, err)
+		assert.Equal(t, cart.Type{ID: test.id, Name: "MBC0", Description: test.description, Battery: test.battery}, c.Type())
+		c.Write(0xA000, 0x12)
+		c.Write(0xBFFF, 0x34)
+		assert.Equal(t, byte(0x12), c.Read(0xA000))
+		assert.Equal(t, byte(0x34), c.Read(0xBFFF))
+		assert.Equal(t, 1, c.ROMBank())
+	}
+}
+
+func TestSaveDataRoundTrip(t *testing.T) {
+	tests := []struct {
+		description string
+		id, ramSize byte
+		expected    int
+		value       byte
+	}{
+		{"ROM+RAM+BATTERY", 0x09, 0x02, 0x2000, 0x5A},
+		{"MBC1+RAM+BATTERY", 0x03, 0x03, 0x8000, 0x5A},
+		{"MBC2+BATTERY", 0x06, 0x00, 512, 0xFA},
+		{"MBC3+RAM+BATTERY", 0x13, 0x03, 0x8000, 0x5A},
+		{"MBC5+RAM+BATTERY", 0x1B, 0x04, 0x20000, 0x5A},
+	}
+
+	for _, test := range tests {
+		saved, err := cart.NewCart(buildROM(4, test.id, test.ramSize))
+		assert.NoError(t, err)
+		saved.Write(0x0000, 0x0A)
+		saved.Write(0xA010, 0x5A)
+
+		data := saved.SaveData()
+		assert.Len(t, data, test.expected, test.description)
+
+		loaded, err := cart.NewCart(buildROM(4, test.id, test.ramSize))
+		assert.NoError(t, err)
+		assert.NoError(t, loaded.LoadSaveData(data), test.description)
+		loaded.Write(0x0000, 0x0A)
+		assert.Equal(t, test.value, loaded.Read(0xA010), test.description)
+	}
+}
+
+func TestSaveDataIsEmptyWithoutBattery(t *testing.T) {
+	for _, id := range []byte{0x00, 0x02, 0x08, 0x12, 0x1A} {
+		c, err := cart.NewCart(buildROM(4, id, 0x02))
+		assert.NoError(t, err)
+		assert.Nil(t, c.SaveData(), "Cart type %#02x", id)
+		assert.Error(t, c.LoadSaveData(make([]byte, 0x2000)), "Cart type %#02x", id)
+	}
+}
+
+func TestLoadSaveDataRejectsSizeMismatch(t *testing.T) {
+	c, err := cart.NewCart(buildROM(4, 0x03, 0x02))
+	assert.NoError(t, err)
+	assert.Error(t, c.LoadSaveData(make([]byte, 0x1000)))
+	assert.Error(t, c.LoadSaveData(make([]byte, 0x8000)))
+	assert.NoError(t, c.LoadSaveData(make([]byte, 0x2000)))
+}
@@ -4,17 +4,14 @@ package cart
 
 import (
 	"errors"
-	"fmt"
-	"strings"
+	"time"
 )
 
-const titleStartAddr, titleEndAddr = 0x134, 0x143
-const cartTypeAddr = 0x147
-
 // MemoryBankController interface for the MBC
 type MemoryBankController interface {
 	Read(addr uint16) byte
 	Write(addr uint16, data byte)
+	ROMBank() int
 }
 
 // Type defines the cartdrige type
@@ -22,52 +19,171 @@ type Type struct {
 	ID          byte
 	Name        string
 	Description string
+	Rumble      bool
+	Battery     bool
 }
 
+// ReadFilter can replace the values read from cart ROM, like a Game Genie does
+type ReadFilter func(address uint16, value byte) byte
+
 // Cart contains the cartdridge data
 type Cart struct {
+	header     *Header
 	controller MemoryBankController
+	filter     ReadFilter
 }
 
 func (cart *Cart) Read(address uint16) byte {
-	return cart.controller.Read(address)
+	value := cart.controller.Read(address)
+	if cart.filter != nil && address <= 0x7FFF {
+		return cart.filter(address, value)
+	}
+	return value
+}
+
+// SetReadFilter sets the filter applied to ROM reads, or removes it if nil
+func (cart *Cart) SetReadFilter(filter ReadFilter) {
+	cart.filter = filter
 }
 
 func (cart *Cart) Write(address uint16, data byte) {
 	cart.controller.Write(address, data)
 }
 
+// ROMBank returns the ROM bank mapped in the switchable area
+func (cart *Cart) ROMBank() int {
+	return cart.controller.ROMBank()
+}
+
+// ROMBankAt returns the ROM bank mapped at an address in cart ROM. Bank 0
+// is mapped at 0x0000-0x3FFF unless the controller can remap that area.
+func (cart *Cart) ROMBankAt(address uint16) int {
+	if address >= 0x4000 {
+		return cart.controller.ROMBank()
+	}
+	if low, ok := cart.controller.(interface{ LowROMBank() int }); ok {
+		return low.LowROMBank()
+	}
+	return 0
+}
+
+// RAMBank returns the cart RAM bank mapped at 0xA000-0xBFFF
+func (cart *Cart) RAMBank() int {
+	if banked, ok := cart.controller.(interface{ RAMBank() int }); ok {
+		return banked.RAMBank()
+	}
+	return 0
+}
+
+// SetRumble sets the handler notified when the cart rumble motor changes.
+// It is a no-op for carts without a motor.
+func (cart *Cart) SetRumble(rumble Rumble) {
+	if r, ok := cart.controller.(interface{ SetRumble(Rumble) }); ok {
+		r.SetRumble(rumble)
+	}
+}
+
+// SaveData returns the battery-backed state to be written to a .sav file.
+// It returns nil for carts without a battery.
+func (cart *Cart) SaveData() []byte {
+	if !cart.Type().Battery {
+		return nil
+	}
+	if battery, ok := cart.controller.(Battery); ok {
+		return battery.SaveData()
+	}
+	return nil
+}
+
+// LoadSaveData restores the battery-backed state read from a .sav file
+func (cart *Cart) LoadSaveData(data []byte) error {
+	battery, ok := cart.controller.(Battery)
+	if !cart.Type().Battery || !ok {
+		return errors.New("Cart has no battery-backed memory")
+	}
+	return battery.LoadSaveData(data)
+}
+
+// Header gets the cartdrige header
+func (cart *Cart) Header() Header {
+	return *cart.header
+}
+
 // Title gets title for the cartdrige
 func (cart *Cart) Title() string {
-	title := ""
-	for address := uint16(titleStartAddr); address < titleEndAddr; address++ {
-		chr := cart.controller.Read(address)
-		if chr != 0x00 {
-			title += string(chr)
-		}
-	}
-	return strings.TrimSpace(title)
+	return cart.header.Title
 }
 
 // Type gets cartdrige type
 func (cart *Cart) Type() Type {
-	cartTypeID := cart.controller.Read(cartTypeAddr)
-	if cartTypeID == 0 {
-		return Type{ID: cartTypeID, Name: "MBC0", Description: "ROM only"}
-	}
-	return Type{ID: cartTypeID, Name: "UNKNOWN", Description: "Unknown"}
+	return cart.header.Type
 }
 
-// NewCart loads cartdridge information from file
+// NewCart loads cartdridge information from file.
+// Checksums and logo are not enforced, see Header.Verify.
 func NewCart(data []byte) (*Cart, error) {
-	cartTypeID := data[cartTypeAddr]
-	if cartTypeID != 0 {
-		msg := fmt.Sprintf("Unknown memory controller (%#02x). Cannot load ROM.", cartTypeID)
-		return nil, errors.New(msg)
+	header, err := ParseHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < header.ROMSize {
+		return nil, &TruncatedError{Size: len(data), Expected: header.ROMSize}
+	}
+	err = checkController(header, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var controller MemoryBankController
+	switch header.Type.ID {
+	case 0x00:
+		controller = NewMBC0(data, 0)
+	case 0x08, 0x09:
+		controller = NewMBC0(data, header.RAMSize)
+	case 0x01:
+		controller, err = NewMBC1(data, 0)
+	case 0x02, 0x03:
+		controller, err = NewMBC1(data, header.RAMSize)
+	case 0x05, 0x06:
+		controller, err = NewMBC2(data)
+	case 0x0F, 0x10:
+		controller, err = NewMBC3(data, header.RAMSize, time.Now)
+	case 0x11, 0x12, 0x13:
+		controller, err = NewMBC3(data, header.RAMSize, nil)
+	case 0x19, 0x1A, 0x1B:
+		controller, err = NewMBC5(data, header.RAMSize, false)
+	case 0x1C, 0x1D, 0x1E:
+		controller, err = NewMBC5(data, header.RAMSize, true)
+	default:
+		return nil, &UnsupportedTypeError{Type: header.Type}
+	}
+	if err != nil {
+		return nil, err
 	}
 
 	cart := &Cart{
-		controller: NewMBC0(data),
+		header:     header,
+		controller: controller,
 	}
 	return cart, nil
 }
+
+// checkController rejects headers declaring sizes the memory controller
+// of the cart type cannot work with
+func checkController(header *Header, data []byte) error {
+	if banks, ok := maxROMBanks[header.Type.Name]; ok && header.ROMSize > banks*romBankSize {
+		return &IncompatibleHeaderError{Type: header.Type, Field: "ROM size", Address: romSizeAddr, Value: data[romSizeAddr]}
+	}
+	switch header.Type.ID {
+	case 0x05, 0x06:
+		// MBC2 has its own RAM, and cannot address external RAM
+		if header.RAMSize != 0 {
+			return &IncompatibleHeaderError{Type: header.Type, Field: "RAM size", Address: ramSizeAddr, Value: data[ramSizeAddr]}
+		}
+	case 0x08, 0x09:
+		if header.RAMSize == 0 {
+			return &IncompatibleHeaderError{Type: header.Type, Field: "RAM size", Address: ramSizeAddr, Value: data[ramSizeAddr]}
+		}
+	}
+	return nil
+}
@@ -40,3 +40,53 @@ func loadTestCart() (*cart.Cart, error) {
 
 	return cart, err
 }
+
+func TestROMOnlyCartHasNoExternalRAM(t *testing.T) {
+	c, err := loadTestCart()
+	assert.NoError(t, err)
+	c.Write(0xA000, 0x12)
+	assert.Equal(t, byte(0xFF), c.Read(0xA000))
+	assert.Equal(t, byte(0xFF), c.Read(0xBFFF))
+}
+
+func TestCartReportsMappedRAMBank(t *testing.T) {
+	tests := []struct {
+		description string
+		cartType    byte
+		ramSize     byte
+		writes      [][2]uint16
+		expected    int
+	}{
+		{"ROM+RAM has a single bank", 0x08, 0x02, nil, 0},
+		{"MBC1 maps bank 0 in mode 0", 0x03, 0x03, [][2]uint16{{0x4000, 0x02}}, 0},
+		{"MBC1 maps BANK2 in mode 1", 0x03, 0x03, [][2]uint16{{0x4000, 0x02}, {0x6000, 0x01}}, 2},
+		{"MBC3 maps the selected bank", 0x10, 0x03, [][2]uint16{{0x4000, 0x03}}, 3},
+		{"MBC3 maps no RAM bank over an RTC register", 0x10, 0x03, [][2]uint16{{0x4000, 0x08}}, -1},
+		{"MBC5 wraps the bank to the RAM size", 0x1B, 0x03, [][2]uint16{{0x4000, 0x06}}, 2},
+		{"MBC5 with a single bank", 0x1A, 0x02, [][2]uint16{{0x4000, 0x03}}, 0},
+	}
+
+	for _, test := range tests {
+		c, err := cart.NewCart(buildROM(4, test.cartType, test.ramSize))
+		assert.NoError(t, err, test.description)
+		for _, write := range test.writes {
+			c.Write(write[0], byte(write[1]))
+		}
+		assert.Equal(t, test.expected, c.RAMBank(), test.description)
+	}
+}
+
+func TestReadFilterAppliesToROMReads(t *testing.T) {
+	c, err := cart.NewCart(buildROM(2, 0x08, 0x02))
+	assert.NoError(t, err)
+	c.Write(0xA000, 0x12)
+	c.SetReadFilter(func(address uint16, value byte) byte {
+		return value + 1
+	})
+	assert.Equal(t, byte(0x01), c.Read(0x0000))
+	assert.Equal(t, byte(0x02), c.Read(0x7FFF))
+	assert.Equal(t, byte(0x12), c.Read(0xA000), "Cart RAM is not filtered")
+
+	c.SetReadFilter(nil)
+	assert.Equal(t, byte(0x00), c.Read(0x0000))
+}
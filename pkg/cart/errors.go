@@ -0,0 +1,63 @@
+package cart
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidLogo is returned when the header does not hold the Nintendo logo the boot ROM checks
+var ErrInvalidLogo = errors.New("Nintendo logo does not match, the boot ROM would lock up")
+
+// TruncatedError is returned for ROM images shorter than their header requires
+type TruncatedError struct {
+	Size     int
+	Expected int
+}
+
+func (e *TruncatedError) Error() string {
+	return fmt.Sprintf("ROM image is truncated: %d bytes long, expected at least %d", e.Size, e.Expected)
+}
+
+// InvalidHeaderError is returned when a header field holds a value with no meaning
+type InvalidHeaderError struct {
+	Field   string
+	Address uint16
+	Value   byte
+}
+
+func (e *InvalidHeaderError) Error() string {
+	return fmt.Sprintf("Invalid %s %#02x at %#04x", e.Field, e.Value, e.Address)
+}
+
+// UnsupportedTypeError is returned for carts whose memory controller is not emulated
+type UnsupportedTypeError struct {
+	Type Type
+}
+
+func (e *UnsupportedTypeError) Error() string {
+	return fmt.Sprintf("Unknown memory controller %s (%#02x). Cannot load ROM.", e.Type.Description, e.Type.ID)
+}
+
+// IncompatibleHeaderError is returned when a header field holds a value the
+// memory controller of the cart type cannot work with
+type IncompatibleHeaderError struct {
+	Type    Type
+	Field   string
+	Address uint16
+	Value   byte
+}
+
+func (e *IncompatibleHeaderError) Error() string {
+	return fmt.Sprintf("%s carts cannot have %s %#02x at %#04x", e.Type.Description, e.Field, e.Value, e.Address)
+}
+
+// ChecksumError is returned when a checksum stored in the header does not match the image
+type ChecksumError struct {
+	Checksum string
+	Expected uint16
+	Actual   uint16
+}
+
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("Invalid %s checksum: header says %#04x, image sums to %#04x", e.Checksum, e.Expected, e.Actual)
+}
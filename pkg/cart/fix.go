@@ -0,0 +1,99 @@
+package cart
+
+import "fmt"
+
+// maxROMBanks is the number of ROM banks each controller can address
+var maxROMBanks = map[string]int{
+	"MBC0": 2,
+	"MBC1": 128,
+	"MBC2": 16,
+	"MBC3": 128,
+	"MBC5": 512,
+}
+
+// SetTitle writes the title to the header. CGB carts only have 11 characters,
+// as the rest of the field holds the manufacturer code and CGB flag.
+func SetTitle(data []byte, title string) error {
+	if len(data) < headerEndAddr {
+		return &TruncatedError{Size: len(data), Expected: headerEndAddr}
+	}
+	end := cgbFlagAddr + 1
+	if data[cgbFlagAddr]&CGBSupported != 0 {
+		end = manufacturerAddr
+	}
+	if len(title) > end-titleAddr {
+		return fmt.Errorf("Title %q is longer than %d characters", title, end-titleAddr)
+	}
+	for i := titleAddr; i < end; i++ {
+		data[i] = 0x00
+	}
+	copy(data[titleAddr:end], title)
+	return nil
+}
+
+// SetCGBFlag writes the CGB flag to the header
+func SetCGBFlag(data []byte, flag byte) error {
+	if len(data) < headerEndAddr {
+		return &TruncatedError{Size: len(data), Expected: headerEndAddr}
+	}
+	data[cgbFlagAddr] = flag
+	return nil
+}
+
+// SetSGB enables SGB functions, which also needs the new licensee code in use
+func SetSGB(data []byte) error {
+	if len(data) < headerEndAddr {
+		return &TruncatedError{Size: len(data), Expected: headerEndAddr}
+	}
+	data[sgbFlagAddr] = 0x03
+	data[oldLicenseeAddr] = useNewLicensee
+	return nil
+}
+
+// SetLogo writes the Nintendo logo the boot ROM checks to the header
+func SetLogo(data []byte) error {
+	if len(data) < headerEndAddr {
+		return &TruncatedError{Size: len(data), Expected: headerEndAddr}
+	}
+	copy(data[logoAddr:], nintendoLogo)
+	return nil
+}
+
+// Pad fills the image with value up to the smallest ROM size that holds it
+// and updates the ROM size in the header to match
+func Pad(data []byte, value byte) ([]byte, error) {
+	if len(data) < headerEndAddr {
+		return nil, &TruncatedError{Size: len(data), Expected: headerEndAddr}
+	}
+	code := byte(0)
+	for ; romSizes[code]*romBankSize < len(data); code++ {
+		if _, ok := romSizes[code+1]; !ok {
+			return nil, fmt.Errorf("ROM image of %d bytes is larger than any ROM size", len(data))
+		}
+	}
+
+	cartType := typeOf(data[cartTypeAddr])
+	if banks, ok := maxROMBanks[cartType.Name]; ok && romSizes[code] > banks {
+		return nil, fmt.Errorf("%s carts cannot address %d ROM banks", cartType.Name, romSizes[code])
+	}
+
+	padded := make([]byte, romSizes[code]*romBankSize)
+	copy(padded, data)
+	for i := len(data); i < len(padded); i++ {
+		padded[i] = value
+	}
+	padded[romSizeAddr] = code
+	return padded, nil
+}
+
+// FixChecksums writes the header and global checksums matching the image
+func FixChecksums(data []byte) error {
+	if len(data) < headerEndAddr {
+		return &TruncatedError{Size: len(data), Expected: headerEndAddr}
+	}
+	data[headerChecksumAddr] = HeaderChecksum(data)
+	global := GlobalChecksum(data)
+	data[globalChecksumAddr] = byte(global >> 8)
+	data[globalChecksumAddr+1] = byte(global)
+	return nil
+}
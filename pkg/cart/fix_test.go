@@ -0,0 +1,119 @@
+package cart_test
+
+import (
+	"testing"
+
+	"github.com/gorkaio/gboy/pkg/cart"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFixChecksums(t *testing.T) {
+	data := buildROM(4, 0x01, 0x00)
+	data[0x148] = 0x01
+	assert.NoError(t, cart.FixChecksums(data))
+
+	header, err := cart.ParseHeader(data)
+	assert.NoError(t, err)
+	assert.NoError(t, header.Verify())
+}
+
+func TestSetTitle(t *testing.T) {
+	data := buildROM(2, 0x00, 0x00)
+	copy(data[0x134:], "OLD LONG TITLE!!")
+	assert.NoError(t, cart.SetTitle(data, "NEW"))
+	header, _ := cart.ParseHeader(data)
+	assert.Equal(t, "NEW", header.Title)
+
+	assert.NoError(t, cart.SetTitle(data, "SIXTEEN CHARS 16"))
+	header, _ = cart.ParseHeader(data)
+	assert.Equal(t, "SIXTEEN CHARS 16", header.Title)
+	assert.Error(t, cart.SetTitle(data, "SEVENTEEN CHARS17"))
+}
+
+func TestSetTitleKeepsCGBFields(t *testing.T) {
+	data := buildROM(2, 0x00, 0x00)
+	copy(data[0x13F:], "ABCD")
+	assert.NoError(t, cart.SetCGBFlag(data, cart.CGBSupported))
+	assert.Error(t, cart.SetTitle(data, "TWELVE CHARS"))
+	assert.NoError(t, cart.SetTitle(data, "ELEVEN CHRS"))
+
+	header, _ := cart.ParseHeader(data)
+	assert.Equal(t, "ELEVEN CHRS", header.Title)
+	assert.Equal(t, "ABCD", header.Manufacturer)
+	assert.True(t, header.CGB())
+	assert.False(t, header.CGBOnly())
+}
+
+func TestSetSGBUsesNewLicensee(t *testing.T) {
+	data := buildROM(2, 0x00, 0x00)
+	data[0x14B] = 0x01
+	assert.NoError(t, cart.SetSGB(data))
+
+	header, _ := cart.ParseHeader(data)
+	assert.True(t, header.SGB)
+	assert.Equal(t, byte(0x33), header.OldLicensee)
+}
+
+func TestSetLogo(t *testing.T) {
+	data := make([]byte, 0x8000)
+	assert.NoError(t, cart.SetLogo(data))
+	header, _ := cart.ParseHeader(data)
+	assert.True(t, header.LogoValid())
+}
+
+func TestPad(t *testing.T) {
+	tests := []struct {
+		size     int
+		cartType byte
+		expected int
+		code     byte
+	}{
+		{0x150, 0x00, 0x8000, 0x00},
+		{0x8000, 0x00, 0x8000, 0x00},
+		{0x8001, 0x01, 0x10000, 0x01},
+		{0x30000, 0x13, 0x40000, 0x03},
+		{0x500000, 0x19, 0x800000, 0x08},
+	}
+
+	for _, test := range tests {
+		data := make([]byte, test.size)
+		data[0x147] = test.cartType
+		padded, err := cart.Pad(data, 0xFF)
+		assert.NoError(t, err)
+		assert.Len(t, padded, test.expected)
+		assert.Equal(t, test.code, padded[0x148])
+		if test.size < test.expected {
+			assert.Equal(t, byte(0xFF), padded[len(padded)-1])
+		}
+
+		header, err := cart.ParseHeader(padded)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, header.ROMSize)
+	}
+}
+
+func TestPadRejectsROMsTooLargeForTheController(t *testing.T) {
+	data := make([]byte, 0x8001)
+	_, err := cart.Pad(data, 0xFF)
+	assert.Error(t, err, "ROM only carts hold 32KiB")
+
+	data = make([]byte, 0x40001)
+	data[0x147] = 0x05
+	_, err = cart.Pad(data, 0xFF)
+	assert.Error(t, err, "MBC2 addresses 16 banks")
+
+	_, err = cart.Pad(make([]byte, 0x800001), 0xFF)
+	assert.Error(t, err)
+}
+
+func TestFixRejectsTruncatedImages(t *testing.T) {
+	data := make([]byte, 0x100)
+	expected := &cart.TruncatedError{Size: 0x100, Expected: 0x150}
+	assert.Equal(t, expected, cart.FixChecksums(data))
+	assert.Equal(t, expected, cart.SetTitle(data, "A"))
+	assert.Equal(t, expected, cart.SetCGBFlag(data, cart.CGBOnly))
+	assert.Equal(t, expected, cart.SetSGB(data))
+	assert.Equal(t, expected, cart.SetLogo(data))
+	_, err := cart.Pad(data, 0xFF)
+	assert.Equal(t, expected, err)
+}
@@ -0,0 +1,255 @@
+package cart
+
+import "strings"
+
+// Cartridge header layout
+const (
+	logoAddr           = 0x104
+	titleAddr          = 0x134
+	manufacturerAddr   = 0x13F
+	cgbFlagAddr        = 0x143
+	newLicenseeAddr    = 0x144
+	sgbFlagAddr        = 0x146
+	cartTypeAddr       = 0x147
+	romSizeAddr        = 0x148
+	ramSizeAddr        = 0x149
+	destinationAddr    = 0x14A
+	oldLicenseeAddr    = 0x14B
+	versionAddr        = 0x14C
+	headerChecksumAddr = 0x14D
+	globalChecksumAddr = 0x14E
+	headerEndAddr      = 0x150
+)
+
+// CGB flag values
+const (
+	CGBSupported = 0x80
+	CGBOnly      = 0xC0
+)
+
+// useNewLicensee in the old licensee field points to the two character new licensee code
+const useNewLicensee = 0x33
+
+var nintendoLogo = []byte{
+	0xCE, 0xED, 0x66, 0x66, 0xCC, 0x0D, 0x00, 0x0B, 0x03, 0x73, 0x00, 0x83, 0x00, 0x0C, 0x00, 0x0D,
+	0x00, 0x08, 0x11, 0x1F, 0x88, 0x89, 0x00, 0x0E, 0xDC, 0xCC, 0x6E, 0xE6, 0xDD, 0xDD, 0xD9, 0x99,
+	0xBB, 0xBB, 0x67, 0x63, 0x6E, 0x0E, 0xEC, 0xCC, 0xDD, 0xDC, 0x99, 0x9F, 0xBB, 0xB9, 0x33, 0x3E,
+}
+
+var cartTypes = map[byte]Type{
+	0x00: {ID: 0x00, Name: "MBC0", Description: "ROM only"},
+	0x01: {ID: 0x01, Name: "MBC1", Description: "MBC1"},
+	0x02: {ID: 0x02, Name: "MBC1", Description: "MBC1+RAM"},
+	0x03: {ID: 0x03, Name: "MBC1", Description: "MBC1+RAM+BATTERY", Battery: true},
+	0x05: {ID: 0x05, Name: "MBC2", Description: "MBC2"},
+	0x06: {ID: 0x06, Name: "MBC2", Description: "MBC2+BATTERY", Battery: true},
+	0x08: {ID: 0x08, Name: "MBC0", Description: "ROM+RAM"},
+	0x09: {ID: 0x09, Name: "MBC0", Description: "ROM+RAM+BATTERY", Battery: true},
+	0x0B: {ID: 0x0B, Name: "MMM01", Description: "MMM01"},
+	0x0C: {ID: 0x0C, Name: "MMM01", Description: "MMM01+RAM"},
+	0x0D: {ID: 0x0D, Name: "MMM01", Description: "MMM01+RAM+BATTERY", Battery: true},
+	0x0F: {ID: 0x0F, Name: "MBC3", Description: "MBC3+TIMER+BATTERY", Battery: true},
+	0x10: {ID: 0x10, Name: "MBC3", Description: "MBC3+TIMER+RAM+BATTERY", Battery: true},
+	0x11: {ID: 0x11, Name: "MBC3", Description: "MBC3"},
+	0x12: {ID: 0x12, Name: "MBC3", Description: "MBC3+RAM"},
+	0x13: {ID: 0x13, Name: "MBC3", Description: "MBC3+RAM+BATTERY", Battery: true},
+	0x19: {ID: 0x19, Name: "MBC5", Description: "MBC5"},
+	0x1A: {ID: 0x1A, Name: "MBC5", Description: "MBC5+RAM"},
+	0x1B: {ID: 0x1B, Name: "MBC5", Description: "MBC5+RAM+BATTERY", Battery: true},
+	0x1C: {ID: 0x1C, Name: "MBC5", Description: "MBC5+RUMBLE", Rumble: true},
+	0x1D: {ID: 0x1D, Name: "MBC5", Description: "MBC5+RUMBLE+RAM", Rumble: true},
+	0x1E: {ID: 0x1E, Name: "MBC5", Description: "MBC5+RUMBLE+RAM+BATTERY", Rumble: true, Battery: true},
+	0x20: {ID: 0x20, Name: "MBC6", Description: "MBC6"},
+	0x22: {ID: 0x22, Name: "MBC7", Description: "MBC7+SENSOR+RUMBLE+RAM+BATTERY", Rumble: true, Battery: true},
+	0xFC: {ID: 0xFC, Name: "CAMERA", Description: "POCKET CAMERA"},
+	0xFD: {ID: 0xFD, Name: "TAMA5", Description: "BANDAI TAMA5"},
+	0xFE: {ID: 0xFE, Name: "HuC3", Description: "HuC3"},
+	0xFF: {ID: 0xFF, Name: "HuC1", Description: "HuC1+RAM+BATTERY", Battery: true},
+}
+
+// romSizes maps the ROM size header codes to their number of 16KiB banks
+var romSizes = map[byte]int{
+	0x00: 2,
+	0x01: 4,
+	0x02: 8,
+	0x03: 16,
+	0x04: 32,
+	0x05: 64,
+	0x06: 128,
+	0x07: 256,
+	0x08: 512,
+	0x52: 72,
+	0x53: 80,
+	0x54: 96,
+}
+
+var ramSizes = map[byte]int{
+	0x00: 0,
+	0x01: 0x800,
+	0x02: 0x2000,
+	0x03: 0x8000,
+	0x04: 0x20000,
+	0x05: 0x10000,
+}
+
+// Header holds the cartridge information stored at 0x100-0x14F
+type Header struct {
+	Title          string
+	Manufacturer   string
+	CGBFlag        byte
+	SGB            bool
+	OldLicensee    byte
+	NewLicensee    string
+	Licensee       string
+	Type           Type
+	ROMSize        int
+	RAMSize        int
+	Japanese       bool
+	Version        byte
+	HeaderChecksum byte
+	GlobalChecksum uint16
+
+	logo                   bool
+	computedHeaderChecksum byte
+	computedGlobalChecksum uint16
+}
+
+// ParseHeader reads the cartridge header of a ROM image
+func ParseHeader(data []byte) (*Header, error) {
+	if len(data) < headerEndAddr {
+		return nil, &TruncatedError{Size: len(data), Expected: headerEndAddr}
+	}
+
+	banks, ok := romSizes[data[romSizeAddr]]
+	if !ok {
+		return nil, &InvalidHeaderError{Field: "ROM size", Address: romSizeAddr, Value: data[romSizeAddr]}
+	}
+	ramSize, ok := ramSizes[data[ramSizeAddr]]
+	if !ok {
+		return nil, &InvalidHeaderError{Field: "RAM size", Address: ramSizeAddr, Value: data[ramSizeAddr]}
+	}
+
+	header := &Header{
+		CGBFlag:                data[cgbFlagAddr],
+		SGB:                    data[sgbFlagAddr] == 0x03 && data[oldLicenseeAddr] == useNewLicensee,
+		OldLicensee:            data[oldLicenseeAddr],
+		Type:                   typeOf(data[cartTypeAddr]),
+		ROMSize:                banks * romBankSize,
+		RAMSize:                ramSize,
+		Japanese:               data[destinationAddr] == 0x00,
+		Version:                data[versionAddr],
+		HeaderChecksum:         data[headerChecksumAddr],
+		GlobalChecksum:         uint16(data[globalChecksumAddr])<<8 | uint16(data[globalChecksumAddr+1]),
+		logo:                   hasNintendoLogo(data),
+		computedHeaderChecksum: HeaderChecksum(data),
+		computedGlobalChecksum: GlobalChecksum(data),
+	}
+
+	// CGB era carts shortened the title to fit the manufacturer code and CGB flag
+	if header.CGB() {
+		header.Title = headerString(data[titleAddr:manufacturerAddr])
+		header.Manufacturer = headerString(data[manufacturerAddr:cgbFlagAddr])
+	} else {
+		header.Title = headerString(data[titleAddr : cgbFlagAddr+1])
+	}
+
+	if header.OldLicensee == useNewLicensee {
+		header.NewLicensee = string(data[newLicenseeAddr : newLicenseeAddr+2])
+		header.Licensee = newLicensees[header.NewLicensee]
+	} else {
+		header.Licensee = oldLicensees[header.OldLicensee]
+	}
+
+	return header, nil
+}
+
+// CGB tells whether the cart supports Game Boy Color features
+func (h *Header) CGB() bool {
+	return h.CGBFlag&CGBSupported != 0
+}
+
+// CGBOnly tells whether the cart refuses to run on a DMG
+func (h *Header) CGBOnly() bool {
+	return h.CGBFlag == CGBOnly
+}
+
+// LogoValid tells whether the header holds the Nintendo logo
+func (h *Header) LogoValid() bool {
+	return h.logo
+}
+
+// HeaderChecksumValid tells whether the header checksum matches 0x134-0x14C
+func (h *Header) HeaderChecksumValid() bool {
+	return h.HeaderChecksum == h.computedHeaderChecksum
+}
+
+// GlobalChecksumValid tells whether the global checksum matches the whole image.
+// Real hardware never checks it.
+func (h *Header) GlobalChecksumValid() bool {
+	return h.GlobalChecksum == h.computedGlobalChecksum
+}
+
+// Verify checks the Nintendo logo, the header checksum and the global checksum
+func (h *Header) Verify() error {
+	if !h.LogoValid() {
+		return ErrInvalidLogo
+	}
+	if !h.HeaderChecksumValid() {
+		return &ChecksumError{Checksum: "header", Expected: uint16(h.HeaderChecksum), Actual: uint16(h.computedHeaderChecksum)}
+	}
+	if !h.GlobalChecksumValid() {
+		return &ChecksumError{Checksum: "global", Expected: h.GlobalChecksum, Actual: h.computedGlobalChecksum}
+	}
+	return nil
+}
+
+// HeaderChecksum computes the checksum the boot ROM verifies over 0x134-0x14C
+func HeaderChecksum(data []byte) byte {
+	var sum byte
+	for _, b := range data[titleAddr:headerChecksumAddr] {
+		sum = sum - b - 1
+	}
+	return sum
+}
+
+// GlobalChecksum computes the sum of every byte in the image but the global checksum itself
+func GlobalChecksum(data []byte) uint16 {
+	var sum uint16
+	for i, b := range data {
+		if i != globalChecksumAddr && i != globalChecksumAddr+1 {
+			sum += uint16(b)
+		}
+	}
+	return sum
+}
+
+func typeOf(id byte) Type {
+	if cartType, ok := cartTypes[id]; ok {
+		return cartType
+	}
+	return Type{ID: id, Name: "UNKNOWN", Description: "Unknown"}
+}
+
+// headerString reads a padded ASCII header field
+func headerString(data []byte) string {
+	end := len(data)
+	for i, b := range data {
+		if b == 0x00 || b >= 0x80 {
+			end = i
+			break
+		}
+	}
+	return strings.TrimSpace(string(data[:end]))
+}
+
+func hasNintendoLogo(data []byte) bool {
+	if len(data) < logoAddr+len(nintendoLogo) {
+		return false
+	}
+	for i, b := range nintendoLogo {
+		if data[logoAddr+i] != b {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,299 @@
+package cart_test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/gorkaio/gboy/pkg/cart"
+	"github.com/stretchr/testify/assert"
+)
+
+// withChecksums stores valid header and global checksums in the image
+func withChecksums(data []byte) []byte {
+	data[0x14D] = cart.HeaderChecksum(data)
+	global := cart.GlobalChecksum(data)
+	data[0x14E] = byte(global >> 8)
+	data[0x14F] = byte(global)
+	return data
+}
+
+func TestParsesTestROMHeader(t *testing.T) {
+	data, err := ioutil.ReadFile(testrom)
+	assert.NoError(t, err)
+
+	header, err := cart.ParseHeader(data)
+	assert.NoError(t, err)
+	assert.Equal(t, "10 PRINT", header.Title)
+	assert.Equal(t, "", header.Manufacturer)
+	assert.False(t, header.CGB())
+	assert.False(t, header.SGB)
+	assert.Equal(t, byte(0x00), header.OldLicensee)
+	assert.Equal(t, "None", header.Licensee)
+	assert.Equal(t, cart.Type{ID: 0x00, Name: "MBC0", Description: "ROM only"}, header.Type)
+	assert.Equal(t, 0x8000, header.ROMSize)
+	assert.Equal(t, 0, header.RAMSize)
+	assert.False(t, header.Japanese)
+	assert.Equal(t, byte(0x02), header.Version)
+	assert.Equal(t, byte(0xD6), header.HeaderChecksum)
+	assert.Equal(t, uint16(0x88B6), header.GlobalChecksum)
+	assert.True(t, header.LogoValid())
+	assert.True(t, header.HeaderChecksumValid())
+	assert.True(t, header.GlobalChecksumValid())
+	assert.NoError(t, header.Verify())
+}
+
+func TestParsesCGBTitleAndManufacturer(t *testing.T) {
+	tests := []struct {
+		flag         byte
+		cgb, cgbOnly bool
+		title        string
+		manufacturer string
+	}{
+		{0x00, false, false, "POKEMON CRYSTAL", ""},
+		{0x80, true, false, "POKEMON CRY", "STAL"},
+		{0xC0, true, true, "POKEMON CRY", "STAL"},
+	}
+
+	for _, test := range tests {
+		data := buildROM(2, 0x00, 0x00)
+		copy(data[0x134:], "POKEMON CRYSTAL")
+		data[0x143] = test.flag
+
+		header, err := cart.ParseHeader(data)
+		assert.NoError(t, err)
+		assert.Equal(t, test.flag, header.CGBFlag)
+		assert.Equal(t, test.cgb, header.CGB(), "CGB flag %#02x", test.flag)
+		assert.Equal(t, test.cgbOnly, header.CGBOnly(), "CGB flag %#02x", test.flag)
+		assert.Equal(t, test.title, header.Title)
+		assert.Equal(t, test.manufacturer, header.Manufacturer)
+	}
+}
+
+func TestNonCGBTitleSpansSixteenBytes(t *testing.T) {
+	data := buildROM(2, 0x00, 0x00)
+	// The last character sits where CGB carts keep their flag
+	copy(data[0x134:], "SUPER MARIOLAND2")
+
+	header, err := cart.ParseHeader(data)
+	assert.NoError(t, err)
+	assert.False(t, header.CGB())
+	assert.Equal(t, "SUPER MARIOLAND2", header.Title)
+	assert.Equal(t, "", header.Manufacturer)
+}
+
+func TestParsesLicensees(t *testing.T) {
+	tests := []struct {
+		old         byte
+		new         string
+		sgbFlag     byte
+		licensee    string
+		newLicensee string
+		sgb         bool
+	}{
+		{0x01, "", 0x00, "Nintendo", "", false},
+		{0x01, "", 0x03, "Nintendo", "", false},
+		{0x33, "01", 0x03, "Nintendo", "01", true},
+		{0x33, "08", 0x00, "Capcom", "08", false},
+		{0x33, "ZZ", 0x00, "", "ZZ", false},
+		{0xA4, "", 0x00, "Konami", "", false},
+	}
+
+	for _, test := range tests {
+		data := buildROM(2, 0x00, 0x00)
+		data[0x14B] = test.old
+		copy(data[0x144:], test.new)
+		data[0x146] = test.sgbFlag
+
+		header, err := cart.ParseHeader(data)
+		assert.NoError(t, err)
+		assert.Equal(t, test.old, header.OldLicensee)
+		assert.Equal(t, test.newLicensee, header.NewLicensee)
+		assert.Equal(t, test.licensee, header.Licensee)
+		assert.Equal(t, test.sgb, header.SGB, "SGB functions need the new licensee code")
+	}
+}
+
+func TestParsesSizes(t *testing.T) {
+	tests := []struct {
+		romCode, ramCode byte
+		romSize, ramSize int
+	}{
+		{0x00, 0x00, 0x8000, 0},
+		{0x01, 0x01, 0x10000, 0x800},
+		{0x05, 0x03, 0x100000, 0x8000},
+		{0x08, 0x04, 0x800000, 0x20000},
+		{0x52, 0x05, 72 * 0x4000, 0x10000},
+		{0x54, 0x02, 96 * 0x4000, 0x2000},
+	}
+
+	for _, test := range tests {
+		data := buildROM(2, 0x00, test.ramCode)
+		data[0x148] = test.romCode
+
+		header, err := cart.ParseHeader(data)
+		assert.NoError(t, err)
+		assert.Equal(t, test.romSize, header.ROMSize, "ROM size code %#02x", test.romCode)
+		assert.Equal(t, test.ramSize, header.RAMSize, "RAM size code %#02x", test.ramCode)
+	}
+}
+
+func TestParsesFullTypeTable(t *testing.T) {
+	tests := []struct {
+		id          byte
+		name        string
+		description string
+	}{
+		{0x0B, "MMM01", "MMM01"},
+		{0x0D, "MMM01", "MMM01+RAM+BATTERY"},
+		{0x20, "MBC6", "MBC6"},
+		{0x22, "MBC7", "MBC7+SENSOR+RUMBLE+RAM+BATTERY"},
+		{0xFC, "CAMERA", "POCKET CAMERA"},
+		{0xFD, "TAMA5", "BANDAI TAMA5"},
+		{0xFE, "HuC3", "HuC3"},
+		{0xFF, "HuC1", "HuC1+RAM+BATTERY"},
+		{0x04, "UNKNOWN", "Unknown"},
+	}
+
+	for _, test := range tests {
+		header, err := cart.ParseHeader(buildROM(2, test.id, 0x00))
+		assert.NoError(t, err)
+		assert.Equal(t, test.id, header.Type.ID)
+		assert.Equal(t, test.name, header.Type.Name)
+		assert.Equal(t, test.description, header.Type.Description)
+	}
+}
+
+func TestParsesVersionAndDestination(t *testing.T) {
+	data := buildROM(2, 0x00, 0x00)
+	data[0x14A] = 0x00
+	data[0x14C] = 0x02
+
+	header, err := cart.ParseHeader(data)
+	assert.NoError(t, err)
+	assert.True(t, header.Japanese)
+	assert.Equal(t, byte(0x02), header.Version)
+}
+
+func TestVerifiesHeader(t *testing.T) {
+	data := withChecksums(buildROM(2, 0x00, 0x00))
+	header, err := cart.ParseHeader(data)
+	assert.NoError(t, err)
+	assert.NoError(t, header.Verify())
+
+	data = withChecksums(buildROM(2, 0x00, 0x00))
+	data[0x104] = 0x00
+	header, _ = cart.ParseHeader(data)
+	assert.False(t, header.LogoValid())
+	assert.Equal(t, cart.ErrInvalidLogo, header.Verify())
+
+	data = withChecksums(buildROM(2, 0x00, 0x00))
+	data[0x14D]++
+	header, _ = cart.ParseHeader(data)
+	assert.False(t, header.HeaderChecksumValid())
+	assert.IsType(t, &cart.ChecksumError{}, header.Verify())
+	assert.Equal(t, "header", header.Verify().(*cart.ChecksumError).Checksum)
+
+	data = withChecksums(buildROM(2, 0x00, 0x00))
+	data[0x7000]++
+	header, _ = cart.ParseHeader(data)
+	assert.True(t, header.HeaderChecksumValid())
+	assert.False(t, header.GlobalChecksumValid())
+	assert.Equal(t, &cart.ChecksumError{Checksum: "global", Expected: header.GlobalChecksum, Actual: header.GlobalChecksum + 1}, header.Verify())
+}
+
+func TestParseHeaderRejectsTruncatedImages(t *testing.T) {
+	for _, size := range []int{0, 0x100, 0x14F} {
+		_, err := cart.ParseHeader(make([]byte, size))
+		assert.Equal(t, &cart.TruncatedError{Size: size, Expected: 0x150}, err)
+	}
+}
+
+func TestParseHeaderRejectsInvalidSizes(t *testing.T) {
+	data := buildROM(2, 0x00, 0x00)
+	data[0x148] = 0x09
+	_, err := cart.ParseHeader(data)
+	assert.Equal(t, &cart.InvalidHeaderError{Field: "ROM size", Address: 0x148, Value: 0x09}, err)
+
+	data = buildROM(2, 0x00, 0x06)
+	_, err = cart.ParseHeader(data)
+	assert.Equal(t, &cart.InvalidHeaderError{Field: "RAM size", Address: 0x149, Value: 0x06}, err)
+}
+
+func TestNewCartRejectsBrokenImages(t *testing.T) {
+	_, err := cart.NewCart(make([]byte, 0x20))
+	assert.Equal(t, &cart.TruncatedError{Size: 0x20, Expected: 0x150}, err)
+
+	data := buildROM(4, 0x01, 0x00)
+	data[0x148] = 0x03
+	_, err = cart.NewCart(data)
+	assert.Equal(t, &cart.TruncatedError{Size: 0x10000, Expected: 0x40000}, err)
+
+	_, err = cart.NewCart(buildROM(2, 0x20, 0x00))
+	assert.IsType(t, &cart.UnsupportedTypeError{}, err)
+	assert.Equal(t, "Unknown memory controller MBC6 (0x20). Cannot load ROM.", err.Error())
+}
+
+func TestNewCartRejectsHeadersTheControllerCannotWorkWith(t *testing.T) {
+	tests := []struct {
+		description string
+		banks       int
+		cartType    byte
+		romSize     byte
+		ramSize     byte
+		field       string
+		address     uint16
+		value       byte
+	}{
+		{"ROM only beyond two banks", 4, 0x00, 0x01, 0x00, "ROM size", 0x148, 0x01},
+		{"MBC1 beyond 128 banks", 256, 0x01, 0x07, 0x00, "ROM size", 0x148, 0x07},
+		{"MBC2 beyond 16 banks", 32, 0x05, 0x04, 0x00, "ROM size", 0x148, 0x04},
+		{"MBC3 beyond 128 banks", 256, 0x11, 0x07, 0x00, "ROM size", 0x148, 0x07},
+		{"MBC2 declaring external RAM", 2, 0x05, 0x00, 0x02, "RAM size", 0x149, 0x02},
+		{"MBC2+BATTERY declaring external RAM", 2, 0x06, 0x00, 0x01, "RAM size", 0x149, 0x01},
+		{"ROM+RAM without RAM", 2, 0x08, 0x00, 0x00, "RAM size", 0x149, 0x00},
+		{"ROM+RAM+BATTERY without RAM", 2, 0x09, 0x00, 0x00, "RAM size", 0x149, 0x00},
+	}
+
+	for _, test := range tests {
+		data := buildROM(test.banks, test.cartType, test.ramSize)
+		data[0x148] = test.romSize
+		header, err := cart.ParseHeader(data)
+		assert.NoError(t, err, test.description)
+
+		_, err = cart.NewCart(data)
+		expected := &cart.IncompatibleHeaderError{Type: header.Type, Field: test.field, Address: test.address, Value: test.value}
+		assert.Equal(t, expected, err, test.description)
+	}
+
+	_, err := cart.NewCart(buildROM(2, 0x05, 0x02))
+	assert.EqualError(t, err, "MBC2 carts cannot have RAM size 0x02 at 0x0149")
+}
+
+func TestNewCartAcceptsHeadersWithinControllerLimits(t *testing.T) {
+	tests := []struct {
+		banks    int
+		cartType byte
+		romSize  byte
+		ramSize  byte
+	}{
+		{2, 0x00, 0x00, 0x00},
+		{128, 0x01, 0x06, 0x00},
+		{16, 0x05, 0x03, 0x00},
+		{2, 0x08, 0x00, 0x02},
+		{512, 0x19, 0x08, 0x00},
+	}
+
+	for _, test := range tests {
+		data := buildROM(test.banks, test.cartType, test.ramSize)
+		data[0x148] = test.romSize
+		_, err := cart.NewCart(data)
+		assert.NoError(t, err, "Cart type %#02x with %d banks", test.cartType, test.banks)
+	}
+}
+
+func TestNewCartExposesHeader(t *testing.T) {
+	c, err := loadTestCart()
+	assert.NoError(t, err)
+	assert.Equal(t, "10 PRINT", c.Header().Title)
+	assert.Equal(t, c.Type(), c.Header().Type)
+}
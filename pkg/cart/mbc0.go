@@ -2,18 +2,44 @@ package cart
 
 type mbc0 struct {
 	memory []byte
+	ram    []byte
 	MemoryBankController
 }
 
-// NewMBC0 creates a new memory bank controller of type 0
-func NewMBC0(data []byte) MemoryBankController {
+// NewMBC0 creates a new memory bank controller of type 0.
+// ROM+RAM carts wire up to 8KiB of RAM straight to 0xA000-0xBFFF.
+func NewMBC0(data []byte, ramSize int) MemoryBankController {
 	return &mbc0{
 		memory: data,
+		ram:    make([]byte, ramSize),
 	}
 }
 
 func (r *mbc0) Read(addr uint16) byte {
+	if addr >= 0xA000 && addr < 0xC000 && len(r.ram) != 0 {
+		return r.ram[int(addr-0xA000)%len(r.ram)]
+	}
+	if int(addr) >= len(r.memory) || addr > 0x7FFF {
+		// No external RAM to answer reads from 0xA000-0xBFFF
+		return 0xFF
+	}
 	return r.memory[addr]
 }
 
-func (r *mbc0) Write(addr uint16, data byte) {}
+func (r *mbc0) Write(addr uint16, data byte) {
+	if addr >= 0xA000 && addr < 0xC000 && len(r.ram) != 0 {
+		r.ram[int(addr-0xA000)%len(r.ram)] = data
+	}
+}
+
+func (r *mbc0) SaveData() []byte {
+	return saveRAM(r.ram)
+}
+
+func (r *mbc0) LoadSaveData(data []byte) error {
+	return loadRAM(r.ram, data)
+}
+
+func (r *mbc0) ROMBank() int {
+	return 1
+}
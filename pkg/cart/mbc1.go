@@ -0,0 +1,151 @@
+package cart
+
+const romBankSize = 0x4000
+const ramBankSize = 0x2000
+
+// minROMSize is the smallest image a banked cart can hold: bank 0 and one switchable bank
+const minROMSize = 2 * romBankSize
+
+type mbc1 struct {
+	rom        []byte
+	ram        []byte
+	ramEnabled bool
+	bank1      byte
+	bank2      byte
+	mode       byte
+	multicart  bool
+}
+
+// NewMBC1 creates a new memory bank controller of type MBC1
+func NewMBC1(data []byte, ramSize int) (MemoryBankController, error) {
+	if err := checkROMSize(data); err != nil {
+		return nil, err
+	}
+	return &mbc1{
+		rom:       data,
+		ram:       make([]byte, ramSize),
+		bank1:     1,
+		multicart: isMBC1Multicart(data),
+	}, nil
+}
+
+// checkROMSize tells whether the image is large enough for a banked controller
+func checkROMSize(data []byte) error {
+	if len(data) < minROMSize {
+		return &TruncatedError{Size: len(data), Expected: minROMSize}
+	}
+	return nil
+}
+
+// isMBC1Multicart detects MBC1M carts, which wire only four bits of the
+// ROM bank register and hold a full game with its own header every 16 banks
+func isMBC1Multicart(data []byte) bool {
+	if len(data) != 64*romBankSize {
+		return false
+	}
+	return hasNintendoLogo(data[0x10*romBankSize:])
+}
+
+func (m *mbc1) Read(addr uint16) byte {
+	switch {
+	case addr < 0x4000:
+		return m.rom[m.romOffset(m.lowBank(), addr)]
+	case addr < 0x8000:
+		return m.rom[m.romOffset(m.highBank(), addr-0x4000)]
+	case addr >= 0xA000 && addr < 0xC000:
+		if !m.ramEnabled || len(m.ram) == 0 {
+			return 0xFF
+		}
+		return m.ram[m.ramOffset(addr)]
+	}
+	return 0xFF
+}
+
+func (m *mbc1) Write(addr uint16, data byte) {
+	switch {
+	case addr < 0x2000:
+		m.ramEnabled = data&0x0F == 0x0A
+	case addr < 0x4000:
+		// Selecting bank 0 maps bank 1, as the zero check only sees these five bits
+		m.bank1 = data & 0x1F
+		if m.bank1 == 0 {
+			m.bank1 = 1
+		}
+	case addr < 0x6000:
+		m.bank2 = data & 0x03
+	case addr < 0x8000:
+		m.mode = data & 0x01
+	case addr >= 0xA000 && addr < 0xC000:
+		if m.ramEnabled && len(m.ram) != 0 {
+			m.ram[m.ramOffset(addr)] = data
+		}
+	}
+}
+
+func (m *mbc1) SaveData() []byte {
+	return saveRAM(m.ram)
+}
+
+func (m *mbc1) LoadSaveData(data []byte) error {
+	return loadRAM(m.ram, data)
+}
+
+func (m *mbc1) ROMBank() int {
+	return m.highBank() % m.romBanks()
+}
+
+// RAMBank returns the RAM bank mapped at 0xA000-0xBFFF
+func (m *mbc1) RAMBank() int {
+	if len(m.ram) == 0 {
+		return 0
+	}
+	return m.ramOffset(0xA000) / ramBankSize
+}
+
+// LowROMBank returns the ROM bank mapped at 0x0000-0x3FFF
+func (m *mbc1) LowROMBank() int {
+	return m.lowBank() % m.romBanks()
+}
+
+func (m *mbc1) romBanks() int {
+	banks := len(m.rom) / romBankSize
+	if banks == 0 {
+		return 1
+	}
+	return banks
+}
+
+func (m *mbc1) bank2Shift() uint {
+	if m.multicart {
+		return 4
+	}
+	return 5
+}
+
+// lowBank is the bank mapped at 0x0000-0x3FFF, which follows BANK2 in mode 1
+func (m *mbc1) lowBank() int {
+	if m.mode == 0 {
+		return 0
+	}
+	return int(m.bank2) << m.bank2Shift()
+}
+
+func (m *mbc1) highBank() int {
+	bank1 := m.bank1
+	if m.multicart {
+		bank1 &= 0x0F
+	}
+	return int(m.bank2)<<m.bank2Shift() | int(bank1)
+}
+
+func (m *mbc1) romOffset(bank int, addr uint16) int {
+	return (bank%m.romBanks())*romBankSize + int(addr)
+}
+
+func (m *mbc1) ramOffset(addr uint16) int {
+	bank := 0
+	if m.mode == 1 {
+		bank = int(m.bank2)
+	}
+	return (bank*ramBankSize + int(addr-0xA000)) % len(m.ram)
+}
@@ -0,0 +1,198 @@
+package cart_test
+
+import (
+	"testing"
+
+	"github.com/gorkaio/gboy/pkg/cart"
+	"github.com/stretchr/testify/assert"
+)
+
+var nintendoLogo = []byte{
+	0xCE, 0xED, 0x66, 0x66, 0xCC, 0x0D, 0x00, 0x0B, 0x03, 0x73, 0x00, 0x83, 0x00, 0x0C, 0x00, 0x0D,
+	0x00, 0x08, 0x11, 0x1F, 0x88, 0x89, 0x00, 0x0E, 0xDC, 0xCC, 0x6E, 0xE6, 0xDD, 0xDD, 0xD9, 0x99,
+	0xBB, 0xBB, 0x67, 0x63, 0x6E, 0x0E, 0xEC, 0xCC, 0xDD, 0xDC, 0x99, 0x9F, 0xBB, 0xB9, 0x33, 0x3E,
+}
+
+// buildROM creates a ROM image where every bank starts and ends with its bank number
+func buildROM(banks int, cartType, ramSize byte) []byte {
+	data := make([]byte, banks*0x4000)
+	for bank := 0; bank < banks; bank++ {
+		data[bank*0x4000] = byte(bank)
+		data[bank*0x4000+0x3FFF] = byte(bank)
+	}
+	copy(data[0x104:], nintendoLogo)
+	data[0x147] = cartType
+	data[0x149] = ramSize
+	return data
+}
+
+func TestMBC1MapsBank1ByDefault(t *testing.T) {
+	mbc, err := cart.NewMBC1(buildROM(8, 0x01, 0x00), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, byte(0), mbc.Read(0x0000))
+	assert.Equal(t, byte(1), mbc.Read(0x4000))
+	assert.Equal(t, byte(1), mbc.Read(0x7FFF))
+	assert.Equal(t, 1, mbc.ROMBank())
+}
+
+func TestMBC1SwitchesROMBanks(t *testing.T) {
+	tests := []struct {
+		bank1, bank2 byte
+		expected     byte
+	}{
+		{0x02, 0x00, 0x02},
+		{0x1F, 0x00, 0x1F},
+		{0x00, 0x00, 0x01},
+		{0xE3, 0x00, 0x03},
+		{0x05, 0x01, 0x25},
+		{0x00, 0x01, 0x21},
+		{0x00, 0x02, 0x41},
+		{0x00, 0x03, 0x61},
+	}
+
+	for _, test := range tests {
+		mbc, err := cart.NewMBC1(buildROM(128, 0x01, 0x00), 0)
+		assert.NoError(t, err)
+		mbc.Write(0x2000, test.bank1)
+		mbc.Write(0x4000, test.bank2)
+		assert.Equal(t, test.expected, mbc.Read(0x4000), "BANK1=%#02x BANK2=%#02x", test.bank1, test.bank2)
+		assert.Equal(t, int(test.expected), mbc.ROMBank())
+	}
+}
+
+func TestMBC1MasksBankToROMSize(t *testing.T) {
+	mbc, err := cart.NewMBC1(buildROM(4, 0x01, 0x00), 0)
+	assert.NoError(t, err)
+	mbc.Write(0x3FFF, 0x06)
+	assert.Equal(t, byte(2), mbc.Read(0x4000))
+	mbc.Write(0x2000, 0x10)
+	assert.Equal(t, byte(0), mbc.Read(0x4000), "Bank 0x10 wraps to bank 0 on a 4 bank ROM")
+}
+
+func TestMBC1Mode1RemapsLowROMArea(t *testing.T) {
+	mbc, err := cart.NewMBC1(buildROM(128, 0x01, 0x00), 0)
+	assert.NoError(t, err)
+	mbc.Write(0x4000, 0x02)
+	assert.Equal(t, byte(0x00), mbc.Read(0x0000), "Mode 0 always maps bank 0 at 0x0000")
+
+	mbc.Write(0x6000, 0x01)
+	assert.Equal(t, byte(0x40), mbc.Read(0x0000), "Mode 1 maps BANK2 at 0x0000")
+	assert.Equal(t, byte(0x41), mbc.Read(0x4000))
+}
+
+func TestCartReportsMBC1BankAtAddress(t *testing.T) {
+	c, err := cart.NewCart(buildROM(128, 0x01, 0x00))
+	assert.NoError(t, err)
+	c.Write(0x2000, 0x01)
+	c.Write(0x4000, 0x02)
+	assert.Equal(t, 0, c.ROMBankAt(0x0000))
+	assert.Equal(t, 0x41, c.ROMBankAt(0x4000))
+
+	c.Write(0x6000, 0x01)
+	assert.Equal(t, 0x40, c.ROMBankAt(0x3FFF), "Mode 1 maps BANK2 at 0x0000")
+	assert.Equal(t, 0x41, c.ROMBankAt(0x7FFF))
+}
+
+func TestMBC1RAMMustBeEnabled(t *testing.T) {
+	mbc, err := cart.NewMBC1(buildROM(4, 0x03, 0x02), 0x2000)
+	assert.NoError(t, err)
+	mbc.Write(0xA000, 0x12)
+	assert.Equal(t, byte(0xFF), mbc.Read(0xA000), "Disabled RAM reads open bus")
+
+	mbc.Write(0x0000, 0x0A)
+	mbc.Write(0xA000, 0x12)
+	mbc.Write(0xBFFF, 0x34)
+	assert.Equal(t, byte(0x12), mbc.Read(0xA000))
+	assert.Equal(t, byte(0x34), mbc.Read(0xBFFF))
+
+	mbc.Write(0x1FFF, 0x0B)
+	assert.Equal(t, byte(0xFF), mbc.Read(0xA000))
+
+	mbc.Write(0x1000, 0xFA)
+	assert.Equal(t, byte(0x12), mbc.Read(0xA000), "Only the low nibble enables RAM")
+}
+
+func TestMBC1WithoutRAMReadsOpenBus(t *testing.T) {
+	mbc, err := cart.NewMBC1(buildROM(4, 0x01, 0x00), 0)
+	assert.NoError(t, err)
+	mbc.Write(0x0000, 0x0A)
+	mbc.Write(0xA000, 0x12)
+	assert.Equal(t, byte(0xFF), mbc.Read(0xA000))
+}
+
+func TestMBC1SwitchesRAMBanksInMode1(t *testing.T) {
+	mbc, err := cart.NewMBC1(buildROM(4, 0x03, 0x03), 0x8000)
+	assert.NoError(t, err)
+	mbc.Write(0x0000, 0x0A)
+	mbc.Write(0x6000, 0x01)
+	for bank := byte(0); bank < 4; bank++ {
+		mbc.Write(0x4000, bank)
+		mbc.Write(0xA000, 0x10+bank)
+	}
+	for bank := byte(0); bank < 4; bank++ {
+		mbc.Write(0x4000, bank)
+		assert.Equal(t, 0x10+bank, mbc.Read(0xA000), "RAM bank %d", bank)
+	}
+
+	mbc.Write(0x6000, 0x00)
+	assert.Equal(t, byte(0x10), mbc.Read(0xA000), "Mode 0 always maps RAM bank 0")
+}
+
+func TestMBC1MulticartUsesFourBitBankRegister(t *testing.T) {
+	data := buildROM(64, 0x01, 0x00)
+	copy(data[0x10*0x4000+0x104:], nintendoLogo)
+	mbc, err := cart.NewMBC1(data, 0)
+	assert.NoError(t, err)
+
+	mbc.Write(0x2000, 0x12)
+	assert.Equal(t, byte(0x02), mbc.Read(0x4000))
+
+	mbc.Write(0x4000, 0x01)
+	assert.Equal(t, byte(0x12), mbc.Read(0x4000))
+
+	mbc.Write(0x6000, 0x01)
+	assert.Equal(t, byte(0x10), mbc.Read(0x0000), "Mode 1 maps the selected game's first bank")
+
+	mbc.Write(0x2000, 0x10)
+	assert.Equal(t, byte(0x10), mbc.Read(0x4000), "Bank 0x10 is not remapped as its low five bits are not zero")
+}
+
+func TestNewCartLoadsMBC1Carts(t *testing.T) {
+	tests := []struct {
+		id          byte
+		description string
+		battery     bool
+	}{
+		{0x01, "MBC1", false},
+		{0x02, "MBC1+RAM", false},
+		{0x03, "MBC1+RAM+BATTERY", true},
+	}
+
+	for _, test := range tests {
+		c, err := cart.NewCart(buildROM(4, test.id, 0x02))
+		assert.NoError(t, err)
+		assert.Equal(t, cart.Type{ID: test.id, Name: "MBC1", Description: test.description, Battery: test.battery}, c.Type())
+		c.Write(0x2000, 0x03)
+		assert.Equal(t, byte(3), c.Read(0x4000))
+		assert.Equal(t, 3, c.ROMBank())
+	}
+}
+
+func TestBankedControllersRejectShortROMs(t *testing.T) {
+	controllers := map[string]func(data []byte) (cart.MemoryBankController, error){
+		"MBC1": func(data []byte) (cart.MemoryBankController, error) { return cart.NewMBC1(data, 0) },
+		"MBC2": cart.NewMBC2,
+		"MBC3": func(data []byte) (cart.MemoryBankController, error) { return cart.NewMBC3(data, 0, nil) },
+		"MBC5": func(data []byte) (cart.MemoryBankController, error) { return cart.NewMBC5(data, 0, false) },
+	}
+
+	for name, newController := range controllers {
+		for _, size := range []int{0, 0x150, 0x4000, 0x7FFF} {
+			mbc, err := newController(make([]byte, size))
+			assert.Nil(t, mbc, "%s with %d bytes", name, size)
+			assert.Equal(t, &cart.TruncatedError{Size: size, Expected: 0x8000}, err, "%s with %d bytes", name, size)
+		}
+		_, err := newController(make([]byte, 0x8000))
+		assert.NoError(t, err, name)
+	}
+}
@@ -0,0 +1,77 @@
+package cart
+
+const mbc2RAMSize = 512
+
+type mbc2 struct {
+	rom        []byte
+	ram        [mbc2RAMSize]byte
+	ramEnabled bool
+	romBank    byte
+}
+
+// NewMBC2 creates a new memory bank controller of type MBC2
+func NewMBC2(data []byte) (MemoryBankController, error) {
+	if err := checkROMSize(data); err != nil {
+		return nil, err
+	}
+	return &mbc2{
+		rom:     data,
+		romBank: 1,
+	}, nil
+}
+
+func (m *mbc2) Read(addr uint16) byte {
+	switch {
+	case addr < 0x4000:
+		return m.rom[int(addr)%len(m.rom)]
+	case addr < 0x8000:
+		return m.rom[(m.ROMBank()*romBankSize+int(addr-0x4000))%len(m.rom)]
+	case addr >= 0xA000 && addr < 0xC000:
+		if !m.ramEnabled {
+			return 0xFF
+		}
+		// Only the low nibble is stored, the upper one reads as 1s
+		return 0xF0 | m.ram[addr&(mbc2RAMSize-1)]
+	}
+	return 0xFF
+}
+
+func (m *mbc2) Write(addr uint16, data byte) {
+	switch {
+	case addr < 0x4000:
+		// Address bit 8 selects between RAM enable and ROM bank registers
+		if addr&0x0100 == 0 {
+			m.ramEnabled = data&0x0F == 0x0A
+			return
+		}
+		m.romBank = data & 0x0F
+		if m.romBank == 0 {
+			m.romBank = 1
+		}
+	case addr >= 0xA000 && addr < 0xC000:
+		if m.ramEnabled {
+			m.ram[addr&(mbc2RAMSize-1)] = data & 0x0F
+		}
+	}
+}
+
+// SaveData returns one byte per half-byte RAM cell
+func (m *mbc2) SaveData() []byte {
+	return saveRAM(m.ram[:])
+}
+
+func (m *mbc2) LoadSaveData(data []byte) error {
+	err := loadRAM(m.ram[:], data)
+	for i := range m.ram {
+		m.ram[i] &= 0x0F
+	}
+	return err
+}
+
+func (m *mbc2) ROMBank() int {
+	banks := len(m.rom) / romBankSize
+	if banks == 0 {
+		return 1
+	}
+	return int(m.romBank) % banks
+}
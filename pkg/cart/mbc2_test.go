@@ -0,0 +1,83 @@
+package cart_test
+
+import (
+	"testing"
+
+	"github.com/gorkaio/gboy/pkg/cart"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMBC2SelectsROMBankWithAddressBit8(t *testing.T) {
+	tests := []struct {
+		addr     uint16
+		data     byte
+		expected byte
+	}{
+		{0x2100, 0x05, 0x05},
+		{0x0100, 0x0F, 0x0F},
+		{0x3FFF, 0x03, 0x03},
+		{0x2100, 0x00, 0x01},
+		{0x2100, 0x12, 0x02},
+		{0x2000, 0x05, 0x01},
+		{0x3EFF, 0x05, 0x01},
+	}
+
+	for _, test := range tests {
+		mbc, err := cart.NewMBC2(buildROM(16, 0x05, 0x00))
+		assert.NoError(t, err)
+		mbc.Write(test.addr, test.data)
+		assert.Equal(t, test.expected, mbc.Read(0x4000), "Writing %#02x to %#04x", test.data, test.addr)
+		assert.Equal(t, int(test.expected), mbc.ROMBank())
+		assert.Equal(t, byte(0), mbc.Read(0x0000))
+	}
+}
+
+func TestMBC2RAMEnableNeedsAddressBit8Clear(t *testing.T) {
+	mbc, err := cart.NewMBC2(buildROM(4, 0x05, 0x00))
+	assert.NoError(t, err)
+	mbc.Write(0x0100, 0x0A)
+	mbc.Write(0xA000, 0x05)
+	assert.Equal(t, byte(0xFF), mbc.Read(0xA000), "Bit 8 set selects the ROM bank register")
+
+	mbc.Write(0x00FF, 0x0A)
+	mbc.Write(0xA000, 0x05)
+	assert.Equal(t, byte(0xF5), mbc.Read(0xA000))
+
+	mbc.Write(0x0000, 0x00)
+	assert.Equal(t, byte(0xFF), mbc.Read(0xA000))
+}
+
+func TestMBC2RAMStoresHalfBytesEchoedAcrossArea(t *testing.T) {
+	mbc, err := cart.NewMBC2(buildROM(4, 0x06, 0x00))
+	assert.NoError(t, err)
+	mbc.Write(0x0000, 0x0A)
+
+	mbc.Write(0xA000, 0xAB)
+	mbc.Write(0xA1FF, 0x3C)
+	assert.Equal(t, byte(0xFB), mbc.Read(0xA000), "Upper nibble reads as 1s")
+	assert.Equal(t, byte(0xFC), mbc.Read(0xA1FF))
+	assert.Equal(t, byte(0xFB), mbc.Read(0xA200), "RAM is echoed every 512 bytes")
+	assert.Equal(t, byte(0xFC), mbc.Read(0xBFFF))
+
+	mbc.Write(0xB005, 0x07)
+	assert.Equal(t, byte(0xF7), mbc.Read(0xA005))
+}
+
+func TestNewCartLoadsMBC2Carts(t *testing.T) {
+	tests := []struct {
+		id          byte
+		description string
+		battery     bool
+	}{
+		{0x05, "MBC2", false},
+		{0x06, "MBC2+BATTERY", true},
+	}
+
+	for _, test := range tests {
+		c, err := cart.NewCart(buildROM(16, test.id, 0x00))
+		assert.NoError(t, err)
+		assert.Equal(t, cart.Type{ID: test.id, Name: "MBC2", Description: test.description, Battery: test.battery}, c.Type())
+		c.Write(0x2100, 0x0E)
+		assert.Equal(t, byte(0x0E), c.Read(0x4000))
+	}
+}
@@ -0,0 +1,130 @@
+package cart
+
+import "fmt"
+
+type mbc3 struct {
+	rom        []byte
+	ram        []byte
+	rtc        *rtc
+	ramEnabled bool
+	romBank    byte
+	ramBank    byte
+}
+
+// NewMBC3 creates a new memory bank controller of type MBC3.
+// Carts with a timer get a real-time clock driven by the given time source.
+func NewMBC3(data []byte, ramSize int, now TimeSource) (MemoryBankController, error) {
+	if err := checkROMSize(data); err != nil {
+		return nil, err
+	}
+	m := &mbc3{
+		rom:     data,
+		ram:     make([]byte, ramSize),
+		romBank: 1,
+	}
+	if now != nil {
+		m.rtc = newRTC(now)
+	}
+	return m, nil
+}
+
+func (m *mbc3) Read(addr uint16) byte {
+	switch {
+	case addr < 0x4000:
+		return m.rom[int(addr)%len(m.rom)]
+	case addr < 0x8000:
+		return m.rom[(m.ROMBank()*romBankSize+int(addr-0x4000))%len(m.rom)]
+	case addr >= 0xA000 && addr < 0xC000:
+		if !m.ramEnabled {
+			return 0xFF
+		}
+		if m.ramBank >= rtcSeconds {
+			if m.rtc == nil {
+				return 0xFF
+			}
+			return m.rtc.Read(m.ramBank)
+		}
+		if len(m.ram) == 0 {
+			return 0xFF
+		}
+		return m.ram[m.ramOffset(addr)]
+	}
+	return 0xFF
+}
+
+func (m *mbc3) Write(addr uint16, data byte) {
+	switch {
+	case addr < 0x2000:
+		m.ramEnabled = data&0x0F == 0x0A
+	case addr < 0x4000:
+		m.romBank = data & 0x7F
+		if m.romBank == 0 {
+			m.romBank = 1
+		}
+	case addr < 0x6000:
+		m.ramBank = data & 0x0F
+	case addr < 0x8000:
+		if m.rtc != nil {
+			m.rtc.Latch(data)
+		}
+	case addr >= 0xA000 && addr < 0xC000:
+		if !m.ramEnabled {
+			return
+		}
+		if m.ramBank >= rtcSeconds {
+			if m.rtc != nil {
+				m.rtc.Write(m.ramBank, data)
+			}
+			return
+		}
+		if len(m.ram) != 0 {
+			m.ram[m.ramOffset(addr)] = data
+		}
+	}
+}
+
+// RAMBank returns the RAM bank mapped at 0xA000-0xBFFF, or -1 while an RTC
+// register is mapped there instead
+func (m *mbc3) RAMBank() int {
+	if m.ramBank >= rtcSeconds {
+		return -1
+	}
+	if len(m.ram) == 0 {
+		return 0
+	}
+	return m.ramOffset(0xA000) / ramBankSize
+}
+
+func (m *mbc3) ROMBank() int {
+	banks := len(m.rom) / romBankSize
+	if banks == 0 {
+		return 1
+	}
+	return int(m.romBank) % banks
+}
+
+// SaveData returns the external RAM followed by the RTC footer, if the cart has a clock
+func (m *mbc3) SaveData() []byte {
+	data := saveRAM(m.ram)
+	if m.rtc != nil {
+		data = append(data, m.rtc.Marshal()...)
+	}
+	return data
+}
+
+// LoadSaveData restores the external RAM and, when present, the RTC footer
+func (m *mbc3) LoadSaveData(data []byte) error {
+	footer := len(data) - len(m.ram)
+	if footer != 0 && (m.rtc == nil || footer != rtcFooterSize && footer != rtcShortFooterSize) {
+		return fmt.Errorf("Save data is %d bytes long, expected %d bytes of RAM", len(data), len(m.ram))
+	}
+	copy(m.ram, data)
+	if footer != 0 {
+		m.rtc.Unmarshal(data[len(m.ram):])
+	}
+	return nil
+}
+
+func (m *mbc3) ramOffset(addr uint16) int {
+	return (int(m.ramBank)*ramBankSize + int(addr-0xA000)) % len(m.ram)
+}
@@ -0,0 +1,304 @@
+package cart_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gorkaio/gboy/pkg/cart"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Unix(1500000000, 0)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newRTCCart(t *testing.T, clock *fakeClock) cart.MemoryBankController {
+	mbc, err := cart.NewMBC3(buildROM(8, 0x10, 0x03), 0x8000, clock.Now)
+	assert.NoError(t, err)
+	mbc.Write(0x0000, 0x0A)
+	return mbc
+}
+
+func latch(mbc cart.MemoryBankController) {
+	mbc.Write(0x6000, 0x00)
+	mbc.Write(0x6000, 0x01)
+}
+
+func readRTC(mbc cart.MemoryBankController, register byte) byte {
+	mbc.Write(0x4000, register)
+	return mbc.Read(0xA000)
+}
+
+func writeRTC(mbc cart.MemoryBankController, register, data byte) {
+	mbc.Write(0x4000, register)
+	mbc.Write(0xA000, data)
+}
+
+func TestMBC3SwitchesROMBanks(t *testing.T) {
+	tests := []struct {
+		data     byte
+		expected byte
+	}{
+		{0x00, 0x01},
+		{0x01, 0x01},
+		{0x2A, 0x2A},
+		{0x7F, 0x7F},
+		{0xFF, 0x7F},
+	}
+
+	for _, test := range tests {
+		mbc, err := cart.NewMBC3(buildROM(128, 0x11, 0x00), 0, nil)
+		assert.NoError(t, err)
+		mbc.Write(0x2000, test.data)
+		assert.Equal(t, test.expected, mbc.Read(0x4000), "Selecting bank %#02x", test.data)
+		assert.Equal(t, int(test.expected), mbc.ROMBank())
+	}
+}
+
+func TestMBC3SwitchesRAMBanks(t *testing.T) {
+	mbc, err := cart.NewMBC3(buildROM(8, 0x13, 0x03), 0x8000, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, byte(0xFF), mbc.Read(0xA000), "RAM is disabled by default")
+
+	mbc.Write(0x0000, 0x0A)
+	for bank := byte(0); bank < 4; bank++ {
+		mbc.Write(0x4000, bank)
+		mbc.Write(0xA000, 0x10+bank)
+	}
+	for bank := byte(0); bank < 4; bank++ {
+		mbc.Write(0x4000, bank)
+		assert.Equal(t, 0x10+bank, mbc.Read(0xA000))
+	}
+}
+
+func TestMBC3WithoutTimerIgnoresRTCRegisters(t *testing.T) {
+	mbc, err := cart.NewMBC3(buildROM(8, 0x13, 0x03), 0x8000, nil)
+	assert.NoError(t, err)
+	mbc.Write(0x0000, 0x0A)
+	writeRTC(mbc, 0x08, 0x12)
+	latch(mbc)
+	assert.Equal(t, byte(0xFF), readRTC(mbc, 0x08))
+}
+
+func TestMBC3RTCCountsTime(t *testing.T) {
+	clock := newFakeClock()
+	mbc := newRTCCart(t, clock)
+
+	clock.Advance(1*24*time.Hour + 2*time.Hour + 3*time.Minute + 4*time.Second + 500*time.Millisecond)
+	latch(mbc)
+	assert.Equal(t, byte(4), readRTC(mbc, 0x08))
+	assert.Equal(t, byte(3), readRTC(mbc, 0x09))
+	assert.Equal(t, byte(2), readRTC(mbc, 0x0A))
+	assert.Equal(t, byte(1), readRTC(mbc, 0x0B))
+	assert.Equal(t, byte(0), readRTC(mbc, 0x0C))
+
+	clock.Advance(500 * time.Millisecond)
+	latch(mbc)
+	assert.Equal(t, byte(5), readRTC(mbc, 0x08), "Sub-second time is kept between updates")
+}
+
+func TestMBC3RTCNeedsLatchSequence(t *testing.T) {
+	clock := newFakeClock()
+	mbc := newRTCCart(t, clock)
+
+	clock.Advance(10 * time.Second)
+	assert.Equal(t, byte(0), readRTC(mbc, 0x08), "Registers are not latched yet")
+
+	mbc.Write(0x6000, 0x01)
+	assert.Equal(t, byte(0), readRTC(mbc, 0x08), "Writing 0x01 alone does not latch")
+
+	latch(mbc)
+	assert.Equal(t, byte(10), readRTC(mbc, 0x08))
+
+	clock.Advance(10 * time.Second)
+	assert.Equal(t, byte(10), readRTC(mbc, 0x08), "Latched registers hold their value")
+
+	mbc.Write(0x6000, 0x00)
+	mbc.Write(0x6000, 0x02)
+	mbc.Write(0x6000, 0x01)
+	assert.Equal(t, byte(10), readRTC(mbc, 0x08), "0x01 must follow 0x00 straight away")
+
+	latch(mbc)
+	assert.Equal(t, byte(20), readRTC(mbc, 0x08))
+}
+
+func TestMBC3RTCHaltStopsTheClock(t *testing.T) {
+	clock := newFakeClock()
+	mbc := newRTCCart(t, clock)
+
+	writeRTC(mbc, 0x0C, 0x40)
+	clock.Advance(time.Hour)
+	latch(mbc)
+	assert.Equal(t, byte(0), readRTC(mbc, 0x08))
+	assert.Equal(t, byte(0), readRTC(mbc, 0x09))
+	assert.Equal(t, byte(0x40), readRTC(mbc, 0x0C))
+
+	writeRTC(mbc, 0x0C, 0x00)
+	clock.Advance(time.Minute)
+	latch(mbc)
+	assert.Equal(t, byte(0), readRTC(mbc, 0x08))
+	assert.Equal(t, byte(1), readRTC(mbc, 0x09))
+}
+
+func TestMBC3RTCDayCounterCarry(t *testing.T) {
+	clock := newFakeClock()
+	mbc := newRTCCart(t, clock)
+
+	writeRTC(mbc, 0x0B, 0xFF)
+	clock.Advance(24 * time.Hour)
+	latch(mbc)
+	assert.Equal(t, byte(0x00), readRTC(mbc, 0x0B))
+	assert.Equal(t, byte(0x01), readRTC(mbc, 0x0C), "Day counter carries into bit 8")
+
+	clock.Advance(256 * 24 * time.Hour)
+	latch(mbc)
+	assert.Equal(t, byte(0x00), readRTC(mbc, 0x0B))
+	assert.Equal(t, byte(0x80), readRTC(mbc, 0x0C), "Day counter overflow sets the carry bit")
+
+	clock.Advance(24 * time.Hour)
+	latch(mbc)
+	assert.Equal(t, byte(0x01), readRTC(mbc, 0x0B))
+	assert.Equal(t, byte(0x80), readRTC(mbc, 0x0C), "Carry stays set until cleared")
+
+	writeRTC(mbc, 0x0C, 0x00)
+	latch(mbc)
+	assert.Equal(t, byte(0x00), readRTC(mbc, 0x0C))
+}
+
+func TestMBC3RTCWrittenRegisters(t *testing.T) {
+	clock := newFakeClock()
+	mbc := newRTCCart(t, clock)
+
+	writeRTC(mbc, 0x08, 59)
+	writeRTC(mbc, 0x09, 59)
+	writeRTC(mbc, 0x0A, 23)
+	writeRTC(mbc, 0x0B, 0x41)
+	clock.Advance(time.Second)
+	latch(mbc)
+	assert.Equal(t, byte(0), readRTC(mbc, 0x08))
+	assert.Equal(t, byte(0), readRTC(mbc, 0x09))
+	assert.Equal(t, byte(0), readRTC(mbc, 0x0A))
+	assert.Equal(t, byte(0x42), readRTC(mbc, 0x0B))
+}
+
+func TestMBC3RTCOutOfRangeValuesWrapWithoutCarry(t *testing.T) {
+	clock := newFakeClock()
+	mbc := newRTCCart(t, clock)
+
+	writeRTC(mbc, 0x08, 0xFF)
+	latch(mbc)
+	assert.Equal(t, byte(0x3F), readRTC(mbc, 0x08), "Seconds register is 6 bits wide")
+
+	clock.Advance(time.Second)
+	latch(mbc)
+	assert.Equal(t, byte(0), readRTC(mbc, 0x08))
+	assert.Equal(t, byte(0), readRTC(mbc, 0x09), "Wrapping from 63 does not count a minute")
+
+	writeRTC(mbc, 0x0A, 30)
+	clock.Advance(2*time.Hour + 1*time.Second)
+	latch(mbc)
+	assert.Equal(t, byte(0), readRTC(mbc, 0x0A))
+	assert.Equal(t, byte(0), readRTC(mbc, 0x0B), "Wrapping from 31 does not count a day")
+	assert.Equal(t, byte(1), readRTC(mbc, 0x08))
+}
+
+func TestMBC3SaveDataIncludesRTCFooter(t *testing.T) {
+	clock := newFakeClock()
+	mbc := newRTCCart(t, clock)
+	mbc.Write(0x4000, 0x00)
+	mbc.Write(0xA000, 0x42)
+	clock.Advance(2*time.Minute + 5*time.Second)
+	latch(mbc)
+
+	data := mbc.(cart.Battery).SaveData()
+	assert.Len(t, data, 0x8000+48)
+	assert.Equal(t, byte(0x42), data[0])
+	footer := data[0x8000:]
+	assert.Equal(t, []byte{5, 0, 0, 0, 2, 0, 0, 0}, footer[0:8])
+	assert.Equal(t, []byte{5, 0, 0, 0, 2, 0, 0, 0}, footer[20:28])
+	assert.Equal(t, []byte{0x7D, 0x2F, 0x68, 0x59, 0, 0, 0, 0}, footer[40:48])
+}
+
+func TestMBC3LoadSaveDataCatchesUpWithElapsedTime(t *testing.T) {
+	clock := newFakeClock()
+	saved := newRTCCart(t, clock)
+	saved.Write(0x4000, 0x03)
+	saved.Write(0xA123, 0x99)
+	clock.Advance(90 * time.Second)
+	latch(saved)
+	data := saved.(cart.Battery).SaveData()
+
+	clock.Advance(24 * time.Hour)
+	loaded := newRTCCart(t, clock)
+	err := loaded.(cart.Battery).LoadSaveData(data)
+	assert.NoError(t, err)
+
+	loaded.Write(0x4000, 0x03)
+	assert.Equal(t, byte(0x99), loaded.Read(0xA123))
+	assert.Equal(t, byte(30), readRTC(loaded, 0x08), "Latched registers are restored")
+	assert.Equal(t, byte(1), readRTC(loaded, 0x09))
+	assert.Equal(t, byte(0), readRTC(loaded, 0x0B))
+
+	latch(loaded)
+	assert.Equal(t, byte(30), readRTC(loaded, 0x08))
+	assert.Equal(t, byte(1), readRTC(loaded, 0x09))
+	assert.Equal(t, byte(1), readRTC(loaded, 0x0B))
+}
+
+func TestMBC3LoadSaveDataAcceptsShortRTCFooter(t *testing.T) {
+	clock := newFakeClock()
+	saved := newRTCCart(t, clock)
+	clock.Advance(time.Hour)
+	data := saved.(cart.Battery).SaveData()[:0x8000+44]
+
+	loaded := newRTCCart(t, clock)
+	assert.NoError(t, loaded.(cart.Battery).LoadSaveData(data))
+	latch(loaded)
+	assert.Equal(t, byte(1), readRTC(loaded, 0x0A))
+}
+
+func TestMBC3LoadSaveDataRejectsUnexpectedSizes(t *testing.T) {
+	clock := newFakeClock()
+	rtcCart := newRTCCart(t, clock).(cart.Battery)
+	assert.Error(t, rtcCart.LoadSaveData(make([]byte, 0x8000+10)))
+
+	mbc, err := cart.NewMBC3(buildROM(8, 0x13, 0x03), 0x8000, nil)
+	assert.NoError(t, err)
+	ramOnly := mbc.(cart.Battery)
+	assert.NoError(t, ramOnly.LoadSaveData(make([]byte, 0x8000)))
+	assert.Error(t, ramOnly.LoadSaveData(make([]byte, 0x8000+48)))
+}
+
+func TestNewCartLoadsMBC3Carts(t *testing.T) {
+	tests := []struct {
+		id          byte
+		description string
+		battery     bool
+	}{
+		{0x0F, "MBC3+TIMER+BATTERY", true},
+		{0x10, "MBC3+TIMER+RAM+BATTERY", true},
+		{0x11, "MBC3", false},
+		{0x12, "MBC3+RAM", false},
+		{0x13, "MBC3+RAM+BATTERY", true},
+	}
+
+	for _, test := range tests {
+		c, err := cart.NewCart(buildROM(128, test.id, 0x03))
+		assert.NoError(t, err)
+		assert.Equal(t, cart.Type{ID: test.id, Name: "MBC3", Description: test.description, Battery: test.battery}, c.Type())
+		c.Write(0x2000, 0x45)
+		assert.Equal(t, byte(0x45), c.Read(0x4000))
+	}
+}
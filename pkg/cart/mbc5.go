@@ -0,0 +1,109 @@
+package cart
+
+// Rumble is called whenever the cart rumble motor is switched on or off
+type Rumble func(on bool)
+
+type mbc5 struct {
+	rom        []byte
+	ram        []byte
+	ramEnabled bool
+	romBank    uint16
+	ramBank    byte
+	rumble     Rumble
+	hasRumble  bool
+	motorOn    bool
+}
+
+// NewMBC5 creates a new memory bank controller of type MBC5.
+// On rumble carts bit 3 of the RAM bank register drives the motor instead.
+func NewMBC5(data []byte, ramSize int, hasRumble bool) (MemoryBankController, error) {
+	if err := checkROMSize(data); err != nil {
+		return nil, err
+	}
+	return &mbc5{
+		rom:       data,
+		ram:       make([]byte, ramSize),
+		romBank:   1,
+		hasRumble: hasRumble,
+	}, nil
+}
+
+// SetRumble sets the handler notified on rumble motor changes
+func (m *mbc5) SetRumble(rumble Rumble) {
+	m.rumble = rumble
+}
+
+func (m *mbc5) Read(addr uint16) byte {
+	switch {
+	case addr < 0x4000:
+		return m.rom[int(addr)%len(m.rom)]
+	case addr < 0x8000:
+		return m.rom[(m.ROMBank()*romBankSize+int(addr-0x4000))%len(m.rom)]
+	case addr >= 0xA000 && addr < 0xC000:
+		if !m.ramEnabled || len(m.ram) == 0 {
+			return 0xFF
+		}
+		return m.ram[m.ramOffset(addr)]
+	}
+	return 0xFF
+}
+
+func (m *mbc5) Write(addr uint16, data byte) {
+	switch {
+	case addr < 0x2000:
+		// Unlike earlier controllers the whole byte must match
+		m.ramEnabled = data == 0x0A
+	case addr < 0x3000:
+		m.romBank = m.romBank&0x100 | uint16(data)
+	case addr < 0x4000:
+		m.romBank = m.romBank&0xFF | uint16(data&0x01)<<8
+	case addr < 0x6000:
+		if m.hasRumble {
+			m.setMotor(data&0x08 != 0)
+			data &= 0x07
+		}
+		m.ramBank = data & 0x0F
+	case addr >= 0xA000 && addr < 0xC000:
+		if m.ramEnabled && len(m.ram) != 0 {
+			m.ram[m.ramOffset(addr)] = data
+		}
+	}
+}
+
+func (m *mbc5) SaveData() []byte {
+	return saveRAM(m.ram)
+}
+
+func (m *mbc5) LoadSaveData(data []byte) error {
+	return loadRAM(m.ram, data)
+}
+
+// RAMBank returns the RAM bank mapped at 0xA000-0xBFFF
+func (m *mbc5) RAMBank() int {
+	if len(m.ram) == 0 {
+		return 0
+	}
+	return m.ramOffset(0xA000) / ramBankSize
+}
+
+func (m *mbc5) ROMBank() int {
+	banks := len(m.rom) / romBankSize
+	if banks == 0 {
+		return 1
+	}
+	return int(m.romBank) % banks
+}
+
+func (m *mbc5) setMotor(on bool) {
+	if on == m.motorOn {
+		return
+	}
+	m.motorOn = on
+	if m.rumble != nil {
+		m.rumble(on)
+	}
+}
+
+func (m *mbc5) ramOffset(addr uint16) int {
+	return (int(m.ramBank)*ramBankSize + int(addr-0xA000)) % len(m.ram)
+}
@@ -0,0 +1,131 @@
+package cart_test
+
+import (
+	"testing"
+
+	"github.com/gorkaio/gboy/pkg/cart"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMBC5SwitchesROMBanks(t *testing.T) {
+	tests := []struct {
+		low, high byte
+		expected  int
+	}{
+		{0x00, 0x00, 0x000},
+		{0x01, 0x00, 0x001},
+		{0xFF, 0x00, 0x0FF},
+		{0x00, 0x01, 0x100},
+		{0xFF, 0x01, 0x1FF},
+		{0x23, 0xFF, 0x123},
+	}
+
+	for _, test := range tests {
+		mbc, err := cart.NewMBC5(buildROM(512, 0x19, 0x00), 0, false)
+		assert.NoError(t, err)
+		mbc.Write(0x2000, test.low)
+		mbc.Write(0x3000, test.high)
+		assert.Equal(t, test.expected, mbc.ROMBank(), "Selecting bank %#02x%02x", test.high, test.low)
+		assert.Equal(t, byte(test.expected), mbc.Read(0x4000))
+		assert.Equal(t, byte(test.expected), mbc.Read(0x7FFF))
+		assert.Equal(t, byte(0), mbc.Read(0x0000))
+	}
+}
+
+func TestMBC5MapsBank0InSwitchableWindow(t *testing.T) {
+	mbc, err := cart.NewMBC5(buildROM(4, 0x19, 0x00), 0, false)
+	assert.NoError(t, err)
+	assert.Equal(t, byte(1), mbc.Read(0x4000), "Bank 1 is mapped on power up")
+
+	mbc.Write(0x2FFF, 0x00)
+	assert.Equal(t, byte(0), mbc.Read(0x4000))
+	assert.Equal(t, 0, mbc.ROMBank())
+}
+
+func TestMBC5SwitchesRAMBanks(t *testing.T) {
+	mbc, err := cart.NewMBC5(buildROM(4, 0x1B, 0x04), 0x20000, false)
+	assert.NoError(t, err)
+	mbc.Write(0x0000, 0x0A)
+	for bank := byte(0); bank < 16; bank++ {
+		mbc.Write(0x4000, bank)
+		mbc.Write(0xA000, 0x20+bank)
+		mbc.Write(0xBFFF, 0x40+bank)
+	}
+	for bank := byte(0); bank < 16; bank++ {
+		mbc.Write(0x5FFF, bank)
+		assert.Equal(t, 0x20+bank, mbc.Read(0xA000))
+		assert.Equal(t, 0x40+bank, mbc.Read(0xBFFF))
+	}
+}
+
+func TestMBC5RAMEnableNeedsFullByte(t *testing.T) {
+	mbc, err := cart.NewMBC5(buildROM(4, 0x1A, 0x02), 0x2000, false)
+	assert.NoError(t, err)
+	mbc.Write(0x0000, 0x1A)
+	mbc.Write(0xA000, 0x12)
+	assert.Equal(t, byte(0xFF), mbc.Read(0xA000))
+
+	mbc.Write(0x0000, 0x0A)
+	mbc.Write(0xA000, 0x12)
+	assert.Equal(t, byte(0x12), mbc.Read(0xA000))
+}
+
+func TestMBC5RumbleEvents(t *testing.T) {
+	c, err := cart.NewCart(buildROM(4, 0x1D, 0x03))
+	assert.NoError(t, err)
+	events := []bool{}
+	c.SetRumble(func(on bool) {
+		events = append(events, on)
+	})
+
+	c.Write(0x0000, 0x0A)
+	c.Write(0x4000, 0x08)
+	c.Write(0x4000, 0x09)
+	c.Write(0x4000, 0x01)
+	c.Write(0x4000, 0x0B)
+	assert.Equal(t, []bool{true, false, true}, events, "Only motor changes are reported")
+
+	c.Write(0xA000, 0x55)
+	c.Write(0x4000, 0x03)
+	assert.Equal(t, byte(0x55), c.Read(0xA000), "Motor bit is not part of the RAM bank")
+}
+
+func TestMBC5WithoutRumbleUsesBit3ForRAMBank(t *testing.T) {
+	c, err := cart.NewCart(buildROM(4, 0x1B, 0x04))
+	assert.NoError(t, err)
+	c.SetRumble(func(on bool) {
+		t.Errorf("Unexpected rumble event")
+	})
+
+	c.Write(0x0000, 0x0A)
+	c.Write(0x4000, 0x08)
+	c.Write(0xA000, 0x88)
+	c.Write(0x4000, 0x00)
+	assert.NotEqual(t, byte(0x88), c.Read(0xA000))
+}
+
+func TestNewCartLoadsMBC5Carts(t *testing.T) {
+	tests := []struct {
+		id          byte
+		description string
+		rumble      bool
+		battery     bool
+	}{
+		{0x19, "MBC5", false, false},
+		{0x1A, "MBC5+RAM", false, false},
+		{0x1B, "MBC5+RAM+BATTERY", false, true},
+		{0x1C, "MBC5+RUMBLE", true, false},
+		{0x1D, "MBC5+RUMBLE+RAM", true, false},
+		{0x1E, "MBC5+RUMBLE+RAM+BATTERY", true, true},
+	}
+
+	for _, test := range tests {
+		c, err := cart.NewCart(buildROM(512, test.id, 0x03))
+		assert.NoError(t, err)
+		assert.Equal(t, cart.Type{ID: test.id, Name: "MBC5", Description: test.description, Rumble: test.rumble, Battery: test.battery}, c.Type())
+		c.Write(0x2000, 0x34)
+		c.Write(0x3000, 0x01)
+		assert.Equal(t, byte(0x34), c.Read(0x4000))
+		assert.Equal(t, 0x134, c.ROMBank())
+	}
+}
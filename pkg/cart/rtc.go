@@ -0,0 +1,208 @@
+package cart
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// RTC register selectors written to 0x4000-0x5FFF
+const (
+	rtcSeconds  = 0x08
+	rtcMinutes  = 0x09
+	rtcHours    = 0x0A
+	rtcDaysLow  = 0x0B
+	rtcDaysHigh = 0x0C
+)
+
+// Flags in the upper day counter register
+const (
+	rtcDayBit8 = 0x01
+	rtcHalt    = 0x40
+	rtcCarry   = 0x80
+)
+
+// rtcFooterSize is the size of the RTC state appended to .sav files: five live
+// and five latched registers as 32-bit little-endian words, then a 64-bit unix timestamp.
+// Some emulators write a 32-bit timestamp instead, which makes it 44 bytes long.
+const rtcFooterSize, rtcShortFooterSize = 48, 44
+
+// TimeSource returns the current time to drive the cart real-time clock
+type TimeSource func() time.Time
+
+type rtcRegisters struct {
+	seconds byte
+	minutes byte
+	hours   byte
+	days    uint16
+	halted  bool
+	carry   bool
+}
+
+type rtc struct {
+	live    rtcRegisters
+	latched rtcRegisters
+	now     TimeSource
+	last    time.Time
+	latch   byte
+}
+
+func newRTC(now TimeSource) *rtc {
+	return &rtc{
+		now:   now,
+		last:  now(),
+		latch: 0xFF,
+	}
+}
+
+// Latch copies the live registers into the readable ones on a 0x00, 0x01 write sequence
+func (r *rtc) Latch(data byte) {
+	if r.latch == 0x00 && data == 0x01 {
+		r.update()
+		r.latched = r.live
+	}
+	r.latch = data
+}
+
+func (r *rtc) Read(register byte) byte {
+	return r.latched.read(register)
+}
+
+func (r *rtc) Write(register byte, data byte) {
+	r.update()
+	r.live.write(register, data)
+	if register == rtcSeconds {
+		// Writing the seconds resets the sub-second divider
+		r.last = r.now()
+	}
+}
+
+// update advances the live registers by the whole seconds elapsed since the last update
+func (r *rtc) update() {
+	now := r.now()
+	if r.live.halted {
+		r.last = now
+		return
+	}
+	elapsed := int64(now.Sub(r.last) / time.Second)
+	if elapsed <= 0 {
+		return
+	}
+	r.last = r.last.Add(time.Duration(elapsed) * time.Second)
+	r.live.advance(elapsed)
+}
+
+// Marshal encodes the clock state as the .sav file RTC footer
+func (r *rtc) Marshal() []byte {
+	r.update()
+	footer := make([]byte, rtcFooterSize)
+	for i, register := range []byte{rtcSeconds, rtcMinutes, rtcHours, rtcDaysLow, rtcDaysHigh} {
+		binary.LittleEndian.PutUint32(footer[i*4:], uint32(r.live.read(register)))
+		binary.LittleEndian.PutUint32(footer[20+i*4:], uint32(r.latched.read(register)))
+	}
+	binary.LittleEndian.PutUint64(footer[40:], uint64(r.last.Unix()))
+	return footer
+}
+
+// Unmarshal restores the clock state from a .sav file RTC footer, catching up
+// with the time passed since it was saved
+func (r *rtc) Unmarshal(footer []byte) {
+	for i, register := range []byte{rtcSeconds, rtcMinutes, rtcHours, rtcDaysLow, rtcDaysHigh} {
+		r.live.write(register, byte(binary.LittleEndian.Uint32(footer[i*4:])))
+		r.latched.write(register, byte(binary.LittleEndian.Uint32(footer[20+i*4:])))
+	}
+	var timestamp int64
+	if len(footer) >= rtcFooterSize {
+		timestamp = int64(binary.LittleEndian.Uint64(footer[40:]))
+	} else {
+		timestamp = int64(binary.LittleEndian.Uint32(footer[40:]))
+	}
+	r.last = time.Unix(timestamp, 0)
+	r.update()
+}
+
+func (regs *rtcRegisters) read(register byte) byte {
+	switch register {
+	case rtcSeconds:
+		return regs.seconds
+	case rtcMinutes:
+		return regs.minutes
+	case rtcHours:
+		return regs.hours
+	case rtcDaysLow:
+		return byte(regs.days)
+	case rtcDaysHigh:
+		value := byte(regs.days>>8) & rtcDayBit8
+		if regs.halted {
+			value |= rtcHalt
+		}
+		if regs.carry {
+			value |= rtcCarry
+		}
+		return value
+	}
+	return 0xFF
+}
+
+func (regs *rtcRegisters) write(register byte, data byte) {
+	switch register {
+	case rtcSeconds:
+		regs.seconds = data & 0x3F
+	case rtcMinutes:
+		regs.minutes = data & 0x3F
+	case rtcHours:
+		regs.hours = data & 0x1F
+	case rtcDaysLow:
+		regs.days = regs.days&0x100 | uint16(data)
+	case rtcDaysHigh:
+		regs.days = regs.days&0xFF | uint16(data&rtcDayBit8)<<8
+		regs.halted = data&rtcHalt != 0
+		regs.carry = data&rtcCarry != 0
+	}
+}
+
+// advance counts the given seconds forward. Out of range values written by
+// software keep counting up to their register width and wrap without a carry.
+func (regs *rtcRegisters) advance(seconds int64) {
+	for ; seconds > 0 && !regs.inRange(); seconds-- {
+		regs.tick()
+	}
+	if seconds == 0 {
+		return
+	}
+
+	total := int64(regs.seconds) + int64(regs.minutes)*60 + int64(regs.hours)*3600 + int64(regs.days)*86400 + seconds
+	regs.seconds = byte(total % 60)
+	regs.minutes = byte(total / 60 % 60)
+	regs.hours = byte(total / 3600 % 24)
+	days := total / 86400
+	if days > 0x1FF {
+		regs.carry = true
+	}
+	regs.days = uint16(days & 0x1FF)
+}
+
+func (regs *rtcRegisters) inRange() bool {
+	return regs.seconds < 60 && regs.minutes < 60 && regs.hours < 24
+}
+
+func (regs *rtcRegisters) tick() {
+	regs.seconds = (regs.seconds + 1) & 0x3F
+	if regs.seconds != 60 {
+		return
+	}
+	regs.seconds = 0
+	regs.minutes = (regs.minutes + 1) & 0x3F
+	if regs.minutes != 60 {
+		return
+	}
+	regs.minutes = 0
+	regs.hours = (regs.hours + 1) & 0x1F
+	if regs.hours != 24 {
+		return
+	}
+	regs.hours = 0
+	regs.days = (regs.days + 1) & 0x1FF
+	if regs.days == 0 {
+		regs.carry = true
+	}
+}
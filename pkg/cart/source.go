@@ -0,0 +1,173 @@
+package cart
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// maxROMSize caps how much is read from a source, so a corrupt archive cannot exhaust memory
+const maxROMSize = 512 * romBankSize
+
+// Source provides a ROM image. Zip and gzip archives are unpacked on read.
+type Source interface {
+	ReadROM() ([]byte, error)
+}
+
+type readerSource struct {
+	open func() (io.ReadCloser, error)
+}
+
+// FromFile reads the ROM from a file path
+func FromFile(romfile string) Source {
+	return &readerSource{open: func() (io.ReadCloser, error) {
+		return os.Open(romfile)
+	}}
+}
+
+// FromReader reads the ROM from r, which is consumed on the first read
+func FromReader(r io.Reader) Source {
+	return &readerSource{open: func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(r), nil
+	}}
+}
+
+// FromFS reads the ROM from a file in fsys, like a ROM embedded in the binary
+func FromFS(fsys fs.FS, name string) Source {
+	return &readerSource{open: func() (io.ReadCloser, error) {
+		return fsys.Open(name)
+	}}
+}
+
+func (s *readerSource) ReadROM() ([]byte, error) {
+	r, err := s.open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	data, err := readLimited(r)
+	if err != nil {
+		return nil, err
+	}
+	return unpack(data)
+}
+
+// Load reads a ROM image from the source and creates its cart
+func Load(source Source) (*Cart, error) {
+	data, err := source.ReadROM()
+	if err != nil {
+		return nil, err
+	}
+	return NewCart(data)
+}
+
+// IsArchive returns whether data is a zip or gzip archive rather than a ROM image
+func IsArchive(data []byte) bool {
+	return isGzip(data) || isZip(data)
+}
+
+func isGzip(data []byte) bool {
+	return bytes.HasPrefix(data, []byte{0x1F, 0x8B})
+}
+
+func isZip(data []byte) bool {
+	return bytes.HasPrefix(data, []byte("PK\x03\x04"))
+}
+
+// unpack extracts the ROM from zip and gzip archives, telling them apart by their magic number
+func unpack(data []byte) ([]byte, error) {
+	switch {
+	case isGzip(data):
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		return readLimited(r)
+	case isZip(data):
+		return unzip(data)
+	}
+	return data, nil
+}
+
+// unzip extracts the only .gb or .gbc file in a zip archive
+func unzip(data []byte) ([]byte, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	var rom *zip.File
+	for _, file := range archive.File {
+		ext := strings.ToLower(path.Ext(file.Name))
+		if file.FileInfo().IsDir() || ext != ".gb" && ext != ".gbc" {
+			continue
+		}
+		if rom != nil {
+			return nil, fmt.Errorf("Zip archive holds several ROMs: %s and %s", rom.Name, file.Name)
+		}
+		rom = file
+	}
+	if rom == nil {
+		return nil, fmt.Errorf("Zip archive holds no .gb or .gbc ROM")
+	}
+
+	r, err := rom.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return readLimited(r)
+}
+
+func readLimited(r io.Reader) ([]byte, error) {
+	data, err := ioutil.ReadAll(io.LimitReader(r, maxROMSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxROMSize {
+		return nil, fmt.Errorf("ROM image is larger than %d bytes", maxROMSize)
+	}
+	return data, nil
+}
+
+// WriteFile writes data to a temporary file in the same directory and renames it
+// over the target, so a crash leaves either the old or the new contents behind.
+// New files are created 0644, existing ones keep their mode.
+func WriteFile(filename string, data []byte) error {
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(filename); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(filename), filepath.Base(filename)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	// Temporary files are created 0600
+	err = tmp.Chmod(mode)
+	if err == nil {
+		_, err = tmp.Write(data)
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filename)
+}
@@ -0,0 +1,125 @@
+package cart_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gorkaio/gboy/pkg/cart"
+	"github.com/stretchr/testify/assert"
+)
+
+func readTestROM(t *testing.T) []byte {
+	data, err := ioutil.ReadFile(testrom)
+	assert.NoError(t, err)
+	return data
+}
+
+func zipped(t *testing.T, files map[string][]byte) []byte {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, data := range files {
+		f, err := w.Create(name)
+		assert.NoError(t, err)
+		_, err = f.Write(data)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func gzipped(t *testing.T, data []byte) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write(data)
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestLoadsCartFromSources(t *testing.T) {
+	rom := readTestROM(t)
+	dir, err := ioutil.TempDir("", "gboy")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	zipfile := filepath.Join(dir, "10-print.zip")
+	assert.NoError(t, ioutil.WriteFile(zipfile, zipped(t, map[string][]byte{"README.txt": []byte("readme"), "roms/10-print.GB": rom}), 0644))
+
+	tests := []struct {
+		description string
+		source      cart.Source
+	}{
+		{"file", cart.FromFile(testrom)},
+		{"reader", cart.FromReader(bytes.NewReader(rom))},
+		{"fs", cart.FromFS(fstest.MapFS{"roms/10-print.gb": {Data: rom}}, "roms/10-print.gb")},
+		{"zip file", cart.FromFile(zipfile)},
+		{"gzip", cart.FromReader(bytes.NewReader(gzipped(t, rom)))},
+		{"gbc in zip", cart.FromReader(bytes.NewReader(zipped(t, map[string][]byte{"10-print.gbc": rom})))},
+	}
+
+	for _, test := range tests {
+		c, err := cart.Load(test.source)
+		assert.NoError(t, err, test.description)
+		if err == nil {
+			assert.Equal(t, "10 PRINT", c.Title(), test.description)
+		}
+	}
+}
+
+func TestLoadReportsSourceErrors(t *testing.T) {
+	rom := readTestROM(t)
+	tests := []struct {
+		description string
+		source      cart.Source
+	}{
+		{"missing file", cart.FromFile("missing.gb")},
+		{"missing fs entry", cart.FromFS(fstest.MapFS{}, "missing.gb")},
+		{"zip without ROM", cart.FromReader(bytes.NewReader(zipped(t, map[string][]byte{"README.txt": rom})))},
+		{"zip with several ROMs", cart.FromReader(bytes.NewReader(zipped(t, map[string][]byte{"a.gb": rom, "b.gbc": rom})))},
+		{"corrupt gzip", cart.FromReader(bytes.NewReader([]byte{0x1F, 0x8B, 0x00}))},
+		{"oversized image", cart.FromReader(bytes.NewReader(make([]byte, 0x800001)))},
+		{"oversized gzip", cart.FromReader(bytes.NewReader(gzipped(t, make([]byte, 0x800001))))},
+	}
+
+	for _, test := range tests {
+		_, err := cart.Load(test.source)
+		assert.Error(t, err, test.description)
+	}
+}
+
+func TestWriteFileKeepsTheModeOfExistingFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gboy")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "game.gb")
+	assert.NoError(t, cart.WriteFile(filename, []byte{0x01}))
+	info, err := os.Stat(filename)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0644), info.Mode().Perm(), "New files are created 0644")
+
+	assert.NoError(t, os.Chmod(filename, 0755))
+	assert.NoError(t, cart.WriteFile(filename, []byte{0x02}))
+	info, err = os.Stat(filename)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+	data, err := ioutil.ReadFile(filename)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x02}, data)
+
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 1, "No temporary files are left behind")
+}
+
+func TestIsArchive(t *testing.T) {
+	rom := readTestROM(t)
+	assert.False(t, cart.IsArchive(rom))
+	assert.True(t, cart.IsArchive(gzipped(t, rom)))
+	assert.True(t, cart.IsArchive(zipped(t, map[string][]byte{"game.gb": rom})))
+}
@@ -0,0 +1,116 @@
+// Package cheat implements Game Genie and GameShark cheat codes
+package cheat
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Kind tells the cheat device a code belongs to
+type Kind int
+
+// Cheat devices
+const (
+	GameGenie Kind = iota
+	GameShark
+)
+
+func (k Kind) String() string {
+	if k == GameShark {
+		return "GameShark"
+	}
+	return "Game Genie"
+}
+
+// Code is a decoded cheat code.
+// Game Genie codes replace Value for the ROM byte at Address, only when it reads Compare if HasCompare is set.
+// GameShark codes write Value to Address in RAM every frame. For cart RAM, only while Bank is mapped.
+type Code struct {
+	Code       string
+	Kind       Kind
+	Address    uint16
+	Value      byte
+	Compare    byte
+	HasCompare bool
+	Bank       byte
+}
+
+// CodeError is returned for malformed cheat codes
+type CodeError struct {
+	Code   string
+	Reason string
+}
+
+func (e *CodeError) Error() string {
+	return fmt.Sprintf("Invalid cheat code %q: %s", e.Code, e.Reason)
+}
+
+// Parse decodes a Game Genie code (ABC-DEF or ABC-DEF-GHI) or a GameShark code (ABCDEFGH)
+func Parse(code string) (Code, error) {
+	normalized := normalize(code)
+	if strings.Contains(normalized, "-") {
+		return parseGameGenie(code, normalized)
+	}
+	return parseGameShark(code, normalized)
+}
+
+// parseGameGenie decodes ABC-DEF-GHI: AB is the new value, FCDE the address with F
+// inverted, and GI the compare value XORed with 0xBA and rotated left by two. H is not used.
+func parseGameGenie(code, normalized string) (Code, error) {
+	if len(normalized) != 7 && len(normalized) != 11 || normalized[3] != '-' || len(normalized) == 11 && normalized[7] != '-' {
+		return Code{}, &CodeError{Code: code, Reason: "Game Genie codes look like ABC-DEF or ABC-DEF-GHI"}
+	}
+	digits := strings.Replace(normalized, "-", "", -1)
+	d := make([]byte, len(digits))
+	for i := range digits {
+		n, err := parseHex(code, digits[i:i+1])
+		if err != nil {
+			return Code{}, err
+		}
+		d[i] = byte(n)
+	}
+
+	address := uint16(d[5]^0xF)<<12 | uint16(d[2])<<8 | uint16(d[3])<<4 | uint16(d[4])
+	if address > 0x7FFF {
+		return Code{}, &CodeError{Code: code, Reason: fmt.Sprintf("address %#04x is outside cart ROM", address)}
+	}
+
+	c := Code{Code: normalized, Kind: GameGenie, Address: address, Value: d[0]<<4 | d[1]}
+	if len(d) == 9 {
+		compare := d[6]<<4 | d[8]
+		c.Compare = (compare>>2 | compare<<6) ^ 0xBA
+		c.HasCompare = true
+	}
+	return c, nil
+}
+
+// parseGameShark decodes ABCDEFGH: AB is the RAM bank, CD the value and GHEF the address
+func parseGameShark(code, normalized string) (Code, error) {
+	if len(normalized) != 8 {
+		return Code{}, &CodeError{Code: code, Reason: "GameShark codes are 8 hex digits long"}
+	}
+	n, err := parseHex(code, normalized)
+	if err != nil {
+		return Code{}, err
+	}
+	address := uint16(n&0xFF)<<8 | uint16(n>>8&0xFF)
+	if address < 0xA000 || address > 0xDFFF {
+		return Code{}, &CodeError{Code: code, Reason: fmt.Sprintf("address %#04x is outside cart and work RAM", address)}
+	}
+	return Code{
+		Code:    normalized,
+		Kind:    GameShark,
+		Bank:    byte(n >> 24),
+		Value:   byte(n >> 16),
+		Address: address,
+	}, nil
+}
+
+func parseHex(code, digits string) (uint64, error) {
+	n, err := strconv.ParseUint(digits, 16, 64)
+	if err != nil {
+		return 0, &CodeError{Code: code, Reason: "codes are made of hex digits"}
+	}
+	return n, nil
+}
@@ -0,0 +1,66 @@
+package cheat_test
+
+import (
+	"testing"
+
+	"github.com/gorkaio/gboy/pkg/cheat"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsesGameGenieCodes(t *testing.T) {
+	tests := []struct {
+		code     string
+		expected cheat.Code
+	}{
+		{"3EA-B0F-AE2", cheat.Code{Code: "3EA-B0F-AE2", Kind: cheat.GameGenie, Address: 0x0AB0, Value: 0x3E, Compare: 0x12, HasCompare: true}},
+		{" 3ea-b0f-ae2 ", cheat.Code{Code: "3EA-B0F-AE2", Kind: cheat.GameGenie, Address: 0x0AB0, Value: 0x3E, Compare: 0x12, HasCompare: true}},
+		{"3EA-B0F-A52", cheat.Code{Code: "3EA-B0F-A52", Kind: cheat.GameGenie, Address: 0x0AB0, Value: 0x3E, Compare: 0x12, HasCompare: true}},
+		{"004-56B-8BB", cheat.Code{Code: "004-56B-8BB", Kind: cheat.GameGenie, Address: 0x4456, Value: 0x00, Compare: 0x58, HasCompare: true}},
+		{"C91-23E", cheat.Code{Code: "C91-23E", Kind: cheat.GameGenie, Address: 0x1123, Value: 0xC9}},
+	}
+
+	for _, test := range tests {
+		code, err := cheat.Parse(test.code)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, code, test.code)
+	}
+}
+
+func TestParsesGameSharkCodes(t *testing.T) {
+	tests := []struct {
+		code     string
+		expected cheat.Code
+	}{
+		{"01FF42C0", cheat.Code{Code: "01FF42C0", Kind: cheat.GameShark, Bank: 0x01, Value: 0xFF, Address: 0xC042}},
+		{"0163DFD0", cheat.Code{Code: "0163DFD0", Kind: cheat.GameShark, Bank: 0x01, Value: 0x63, Address: 0xD0DF}},
+		{"01990AA1", cheat.Code{Code: "01990AA1", Kind: cheat.GameShark, Bank: 0x01, Value: 0x99, Address: 0xA10A}},
+	}
+
+	for _, test := range tests {
+		code, err := cheat.Parse(test.code)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, code, test.code)
+	}
+}
+
+func TestRejectsMalformedCodes(t *testing.T) {
+	tests := []struct {
+		code   string
+		reason string
+	}{
+		{"3EA-B0", "Game Genie codes look like ABC-DEF or ABC-DEF-GHI"},
+		{"3EAB-0F", "Game Genie codes look like ABC-DEF or ABC-DEF-GHI"},
+		{"3EA-B0F-AE", "Game Genie codes look like ABC-DEF or ABC-DEF-GHI"},
+		{"3EA-B0G", "codes are made of hex digits"},
+		{"3EA-B07", "address 0x8ab0 is outside cart ROM"},
+		{"01FF42C", "GameShark codes are 8 hex digits long"},
+		{"01FF42CZ", "codes are made of hex digits"},
+		{"01FF4280", "address 0x8042 is outside cart and work RAM"},
+		{"", "GameShark codes are 8 hex digits long"},
+	}
+
+	for _, test := range tests {
+		_, err := cheat.Parse(test.code)
+		assert.Equal(t, &cheat.CodeError{Code: test.code, Reason: test.reason}, err)
+	}
+}
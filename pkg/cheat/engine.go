@@ -0,0 +1,175 @@
+package cheat
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Cheat is a code in the engine along with its state
+type Cheat struct {
+	Code
+	Description string
+	Enabled     bool
+}
+
+// Writer is where GameShark codes write to
+type Writer interface {
+	Write(address uint16, data byte)
+	RAMBank() int
+}
+
+// Engine holds the cheats for the running game
+type Engine struct {
+	cheats []*Cheat
+	genie  map[uint16]Code
+	shark  []Code
+}
+
+// NewEngine creates an engine without cheats
+func NewEngine() *Engine {
+	return &Engine{genie: map[uint16]Code{}}
+}
+
+// Add parses a code and adds it enabled. Adding a code twice updates its description.
+func (e *Engine) Add(code, description string) error {
+	c, err := Parse(code)
+	if err != nil {
+		return err
+	}
+	if cheat := e.find(c.Code); cheat != nil {
+		cheat.Description = description
+		return nil
+	}
+	e.cheats = append(e.cheats, &Cheat{Code: c, Description: description, Enabled: true})
+	e.update()
+	return nil
+}
+
+// Remove removes a code
+func (e *Engine) Remove(code string) error {
+	normalized := normalize(code)
+	for i, cheat := range e.cheats {
+		if cheat.Code.Code == normalized {
+			e.cheats = append(e.cheats[:i], e.cheats[i+1:]...)
+			e.update()
+			return nil
+		}
+	}
+	return &CodeError{Code: code, Reason: "no such cheat"}
+}
+
+// Enable turns a code on
+func (e *Engine) Enable(code string) error {
+	return e.setEnabled(code, true)
+}
+
+// Disable turns a code off, keeping it in the engine
+func (e *Engine) Disable(code string) error {
+	return e.setEnabled(code, false)
+}
+
+// Clear removes every code
+func (e *Engine) Clear() {
+	e.cheats = nil
+	e.update()
+}
+
+// Cheats lists the codes in the order they were added
+func (e *Engine) Cheats() []Cheat {
+	cheats := make([]Cheat, len(e.cheats))
+	for i, cheat := range e.cheats {
+		cheats[i] = *cheat
+	}
+	return cheats
+}
+
+// Read applies Game Genie codes to a value read from cart ROM.
+// It can be used as a cart.ReadFilter.
+func (e *Engine) Read(address uint16, value byte) byte {
+	if len(e.genie) == 0 {
+		return value
+	}
+	c, ok := e.genie[address]
+	if !ok || c.HasCompare && c.Compare != value {
+		return value
+	}
+	return c.Value
+}
+
+// Frame applies GameShark codes, writing their values to RAM once per frame.
+// Codes for cart RAM are only written while their bank is mapped.
+func (e *Engine) Frame(mem Writer) {
+	for _, c := range e.shark {
+		if c.Address <= 0xBFFF && int(c.Bank) != mem.RAMBank() {
+			continue
+		}
+		mem.Write(c.Address, c.Value)
+	}
+}
+
+// Load adds the codes in a cheat file: one code per line, followed by an optional
+// description. Lines starting with # are comments and codes starting with - are disabled.
+func (e *Engine) Load(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		code, description := text, ""
+		if i := strings.IndexAny(text, " \t"); i != -1 {
+			code, description = text[:i], strings.TrimSpace(text[i:])
+		}
+		disabled := strings.HasPrefix(code, "-")
+		code = strings.TrimPrefix(code, "-")
+
+		if err := e.Add(code, description); err != nil {
+			return fmt.Errorf("Line %d: %w", line, err)
+		}
+		if disabled {
+			e.Disable(code)
+		}
+	}
+	return scanner.Err()
+}
+
+func (e *Engine) setEnabled(code string, enabled bool) error {
+	cheat := e.find(normalize(code))
+	if cheat == nil {
+		return &CodeError{Code: code, Reason: "no such cheat"}
+	}
+	cheat.Enabled = enabled
+	e.update()
+	return nil
+}
+
+func (e *Engine) find(normalized string) *Cheat {
+	for _, cheat := range e.cheats {
+		if cheat.Code.Code == normalized {
+			return cheat
+		}
+	}
+	return nil
+}
+
+// update rebuilds the lookups of enabled codes used on every read and frame
+func (e *Engine) update() {
+	e.genie = map[uint16]Code{}
+	e.shark = nil
+	for _, cheat := range e.cheats {
+		if !cheat.Enabled {
+			continue
+		}
+		if cheat.Kind == GameGenie {
+			e.genie[cheat.Address] = cheat.Code
+		} else {
+			e.shark = append(e.shark, cheat.Code)
+		}
+	}
+}
+
+func normalize(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
@@ -0,0 +1,124 @@
+package cheat_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gorkaio/gboy/pkg/cheat"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordedWrite struct {
+	address uint16
+	data    byte
+}
+
+type fakeMemory struct {
+	writes  []recordedWrite
+	ramBank int
+}
+
+func (m *fakeMemory) Write(address uint16, data byte) {
+	m.writes = append(m.writes, recordedWrite{address, data})
+}
+
+func (m *fakeMemory) RAMBank() int {
+	return m.ramBank
+}
+
+func TestGameGenieReplacesROMReads(t *testing.T) {
+	engine := cheat.NewEngine()
+	assert.NoError(t, engine.Add("3EA-B0F-AE2", "compare"))
+	assert.NoError(t, engine.Add("C91-23E", "no compare"))
+
+	assert.Equal(t, byte(0x3E), engine.Read(0x0AB0, 0x12))
+	assert.Equal(t, byte(0x13), engine.Read(0x0AB0, 0x13), "Compare value does not match")
+	assert.Equal(t, byte(0xC9), engine.Read(0x1123, 0x00))
+	assert.Equal(t, byte(0x77), engine.Read(0x1124, 0x77))
+}
+
+func TestGameSharkWritesRAMEveryFrame(t *testing.T) {
+	engine := cheat.NewEngine()
+	assert.NoError(t, engine.Add("01FF42C0", ""))
+	assert.NoError(t, engine.Add("0163DFD0", ""))
+
+	mem := &fakeMemory{}
+	engine.Frame(mem)
+	engine.Frame(mem)
+	assert.Equal(t, []recordedWrite{{0xC042, 0xFF}, {0xD0DF, 0x63}, {0xC042, 0xFF}, {0xD0DF, 0x63}}, mem.writes)
+}
+
+func TestGameSharkWritesCartRAMOnlyWhileItsBankIsMapped(t *testing.T) {
+	engine := cheat.NewEngine()
+	assert.NoError(t, engine.Add("01990AA1", "Cart RAM bank 1"))
+	assert.NoError(t, engine.Add("03FF42C0", "Work RAM ignores the bank"))
+
+	mem := &fakeMemory{}
+	engine.Frame(mem)
+	assert.Equal(t, []recordedWrite{{0xC042, 0xFF}}, mem.writes)
+
+	mem = &fakeMemory{ramBank: 1}
+	engine.Frame(mem)
+	assert.Equal(t, []recordedWrite{{0xA10A, 0x99}, {0xC042, 0xFF}}, mem.writes)
+}
+
+func TestEnablesAndDisablesCodes(t *testing.T) {
+	engine := cheat.NewEngine()
+	assert.NoError(t, engine.Add("C91-23E", "Genie"))
+	assert.NoError(t, engine.Add("01FF42C0", "Shark"))
+
+	assert.NoError(t, engine.Disable("c91-23e"))
+	assert.NoError(t, engine.Disable("01FF42C0"))
+	assert.Equal(t, byte(0x00), engine.Read(0x1123, 0x00))
+	mem := &fakeMemory{}
+	engine.Frame(mem)
+	assert.Empty(t, mem.writes)
+
+	assert.NoError(t, engine.Enable("C91-23E"))
+	assert.Equal(t, byte(0xC9), engine.Read(0x1123, 0x00))
+
+	assert.NoError(t, engine.Remove("C91-23E"))
+	assert.Equal(t, byte(0x00), engine.Read(0x1123, 0x00))
+	assert.Equal(t, []cheat.Cheat{{Code: cheat.Code{Code: "01FF42C0", Kind: cheat.GameShark, Bank: 0x01, Value: 0xFF, Address: 0xC042}, Description: "Shark"}}, engine.Cheats())
+
+	assert.Equal(t, &cheat.CodeError{Code: "C91-23E", Reason: "no such cheat"}, engine.Enable("C91-23E"))
+	assert.Error(t, engine.Remove("C91-23E"))
+
+	engine.Clear()
+	assert.Empty(t, engine.Cheats())
+}
+
+func TestAddReportsMalformedCode(t *testing.T) {
+	engine := cheat.NewEngine()
+	err := engine.Add("C91-23", "")
+	assert.IsType(t, &cheat.CodeError{}, err)
+	assert.Contains(t, err.Error(), `"C91-23"`)
+	assert.Empty(t, engine.Cheats())
+}
+
+func TestLoadsCheatFiles(t *testing.T) {
+	file := `# Infinite everything
+C91-23E	Infinite lives
+-01FF42C0 Max money
+
+3EA-B0F-AE2
+`
+	engine := cheat.NewEngine()
+	assert.NoError(t, engine.Load(strings.NewReader(file)))
+
+	cheats := engine.Cheats()
+	assert.Len(t, cheats, 3)
+	assert.Equal(t, "C91-23E", cheats[0].Code.Code)
+	assert.Equal(t, "Infinite lives", cheats[0].Description)
+	assert.True(t, cheats[0].Enabled)
+	assert.Equal(t, "01FF42C0", cheats[1].Code.Code)
+	assert.Equal(t, "Max money", cheats[1].Description)
+	assert.False(t, cheats[1].Enabled)
+	assert.Equal(t, "", cheats[2].Description)
+}
+
+func TestLoadReportsMalformedLine(t *testing.T) {
+	engine := cheat.NewEngine()
+	err := engine.Load(strings.NewReader("C91-23E\n# comment\nXYZ-123 Broken\n"))
+	assert.EqualError(t, err, `Line 3: Invalid cheat code "XYZ-123": codes are made of hex digits`)
+}
@@ -5,6 +5,7 @@ package cpu
 import (
 	"fmt"
 	"github.com/gorkaio/gboy/pkg/bits"
+	"github.com/gorkaio/gboy/pkg/interrupts"
 	"github.com/olekukonko/tablewriter"
 	"os"
 )
@@ -22,6 +23,12 @@ const (
 	flagC = byte(0x10)
 )
 
+const joypadAddr = 0xFF00
+
+// Ticker is called for every machine cycle the CPU spends, so the rest
+// of the system can advance in lockstep with its memory accesses
+type Ticker func(cycles int)
+
 // CPU structure
 type CPU struct {
 	AF, BC, DE, HL, SP, PC *WordRegister
@@ -29,12 +36,21 @@ type CPU struct {
 	memory                 Memory
 	debugEnabled           bool
 	imeFlag                bool
+	imeScheduled           bool
+	halted                 bool
+	stopped                bool
+	haltBug                bool
+	locked                 bool
+	ticker                 Ticker
+	ticked                 int
 }
 
 // State reflects the CPU status
 type State struct {
 	AF, BC, DE, HL, SP, PC uint16
-	IME                    bool
+	IME, IMEScheduled      bool
+	Halted, Stopped        bool
+	Locked                 bool
 }
 
 // New initialises a new Z80 cpu
@@ -73,11 +89,79 @@ func (cpu *CPU) DebugDisable() {
 	cpu.debugEnabled = false
 }
 
+// SetTicker sets the function called on every machine cycle
+func (cpu *CPU) SetTicker(ticker Ticker) {
+	cpu.ticker = ticker
+}
+
 // Step executes next instruction and returns cycles consumed
 func (cpu *CPU) Step() (int, error) {
-	op, err := cpu.opCodeAt(cpu.PC.Get())
+	cpu.ticked = 0
+	cycles, err := cpu.step()
+	// Internal cycles not spent on memory accesses are ticked at the end
+	for cpu.ticked < cycles {
+		cpu.tick()
+	}
+	return cpu.ticked, err
+}
+
+func (cpu *CPU) tick() {
+	cpu.ticked += 4
+	if cpu.ticker != nil {
+		cpu.ticker(4)
+	}
+}
+
+func (cpu *CPU) step() (int, error) {
+	if cpu.locked {
+		return 4, nil
+	}
+
+	if cpu.stopped {
+		if !cpu.joypadPressed() {
+			return 4, nil
+		}
+		cpu.stopped = false
+	}
+
+	wakeCycles := 0
+	if cpu.halted {
+		if !cpu.interruptPending() {
+			return 4, nil
+		}
+		cpu.halted = false
+		if cpu.imeFlag {
+			// Waking up to dispatch an interrupt takes an extra machine cycle
+			cpu.tick()
+			wakeCycles = 4
+		}
+	}
+
+	if cpu.imeFlag {
+		if cycles, serviced := cpu.serviceInterrupts(); serviced {
+			return wakeCycles + cycles, nil
+		}
+	}
+
+	// EI takes effect after the instruction following it
+	enableIME := cpu.imeScheduled
+
+	var op op
+	var err error
+	if cpu.haltBug {
+		// PC fails to increment after fetching the opcode following HALT,
+		// so its first byte is read twice
+		cpu.haltBug = false
+		op, err = cpu.opCodeWithHaltBugAt(cpu.PC.Get())
+		if err == nil {
+			cpu.PC.Dec()
+		}
+	} else {
+		op, err = cpu.opCodeAt(cpu.PC.Get())
+	}
 	if err != nil {
-		return 0, err
+		cpu.locked = true
+		return 4, err
 	}
 
 	if cpu.debugEnabled {
@@ -85,7 +169,11 @@ func (cpu *CPU) Step() (int, error) {
 		fmt.Println(op.String())
 	}
 
-	cycles := op.handler(cpu, op.args...)
+	cycles := op.handler(cpu, op.arg)
+	if enableIME && cpu.imeScheduled {
+		cpu.imeScheduled = false
+		cpu.imeFlag = true
+	}
 	if cpu.debugEnabled {
 		cpu.printStatus()
 		fmt.Printf("Cycles consumed: %d\n", cycles)
@@ -94,31 +182,27 @@ func (cpu *CPU) Step() (int, error) {
 }
 
 func (cpu *CPU) memoryReadWord(address uint16) uint16 {
-	l := cpu.memory.Read(address)
-	h := cpu.memory.Read(address + 1)
+	l := cpu.memoryReadByte(address)
+	h := cpu.memoryReadByte(address + 1)
 	return bits.ConcatWord(h, l)
 }
 
-func (cpu *CPU) memoryReadDWord(address uint16) uint32 {
-	a := uint32(cpu.memoryReadByte(address))
-	b := uint32(cpu.memoryReadByte(address + 1))
-	c := uint32(cpu.memoryReadByte(address + 2))
-	d := uint32(cpu.memoryReadByte(address + 3))
-	return (a<<24 | b<<16 | c<<8 | d)
-}
-
+// memoryReadByte reads from the bus, taking a machine cycle
 func (cpu *CPU) memoryReadByte(address uint16) uint8 {
+	cpu.tick()
 	return cpu.memory.Read(address)
 }
 
+// memoryWriteByte writes to the bus, taking a machine cycle
 func (cpu *CPU) memoryWriteByte(address uint16, data uint8) {
+	cpu.tick()
 	cpu.memory.Write(address, data)
 }
 
 func (cpu *CPU) memoryWriteWord(address uint16, data uint16) {
 	h, l := bits.SplitWord(data)
-	cpu.memory.Write(address, l)
-	cpu.memory.Write(address+1, h)
+	cpu.memoryWriteByte(address, l)
+	cpu.memoryWriteByte(address+1, h)
 }
 
 // SetFlagZ sets or clears the Zero Flag
@@ -180,6 +264,12 @@ func (cpu *CPU) FlagH() bool {
 // DisableInterrupts clears the interrupt master enable flag
 func (cpu *CPU) DisableInterrupts() {
 	cpu.imeFlag = false
+	cpu.imeScheduled = false
+}
+
+// ScheduleEnableInterrupts sets the interrupt master enable flag after the next instruction
+func (cpu *CPU) ScheduleEnableInterrupts() {
+	cpu.imeScheduled = true
 }
 
 // EnableInterrupts sets the interrupt master enable flag
@@ -192,16 +282,60 @@ func (cpu *CPU) InterruptsEnabled() bool {
 	return cpu.imeFlag
 }
 
+// Halted returns whether the CPU is halted waiting for an interrupt
+func (cpu *CPU) Halted() bool {
+	return cpu.halted
+}
+
+// Stopped returns whether the CPU is stopped waiting for a joypad press
+func (cpu *CPU) Stopped() bool {
+	return cpu.stopped
+}
+
+func (cpu *CPU) interruptPending() bool {
+	ie := cpu.memory.Read(interrupts.EnableAddr)
+	ifr := cpu.memory.Read(interrupts.FlagAddr)
+	return ie&ifr&0x1F != 0
+}
+
+func (cpu *CPU) serviceInterrupts() (int, bool) {
+	ifr := cpu.memory.Read(interrupts.FlagAddr)
+	pending := cpu.memory.Read(interrupts.EnableAddr) & ifr & 0x1F
+	for i := interrupts.VBlank; i <= interrupts.Joypad; i++ {
+		if bits.BitOfByte(pending, uint8(i)) {
+			cpu.DisableInterrupts()
+			cpu.memory.Write(interrupts.FlagAddr, ifr&^(1<<i))
+			cpu.tick()
+			cpu.call(i.Vector())
+			return 20, true
+		}
+	}
+	return 0, false
+}
+
+func (cpu *CPU) joypadPressed() bool {
+	return cpu.memory.Read(joypadAddr)&0x0F != 0x0F
+}
+
+// Locked returns whether the CPU has locked up after an illegal opcode
+func (cpu *CPU) Locked() bool {
+	return cpu.locked
+}
+
 // Status returns the CPU register status
 func (cpu *CPU) Status() State {
 	return State{
-		AF:  cpu.AF.Get(),
-		BC:  cpu.BC.Get(),
-		DE:  cpu.DE.Get(),
-		HL:  cpu.HL.Get(),
-		SP:  cpu.SP.Get(),
-		PC:  cpu.PC.Get(),
-		IME: cpu.imeFlag,
+		AF:           cpu.AF.Get(),
+		BC:           cpu.BC.Get(),
+		DE:           cpu.DE.Get(),
+		HL:           cpu.HL.Get(),
+		SP:           cpu.SP.Get(),
+		PC:           cpu.PC.Get(),
+		IME:          cpu.imeFlag,
+		IMEScheduled: cpu.imeScheduled,
+		Halted:       cpu.halted,
+		Stopped:      cpu.stopped,
+		Locked:       cpu.locked,
 	}
 }
 
@@ -214,6 +348,10 @@ func (cpu *CPU) SetStatus(state State) {
 	cpu.SP.Set(state.SP)
 	cpu.PC.Set(state.PC)
 	cpu.imeFlag = state.IME
+	cpu.imeScheduled = state.IMEScheduled
+	cpu.halted = state.Halted
+	cpu.stopped = state.Stopped
+	cpu.locked = state.Locked
 }
 
 func (cpu *CPU) printStatus() {
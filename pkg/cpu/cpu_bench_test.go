@@ -0,0 +1,64 @@
+package cpu_test
+
+import (
+	"testing"
+
+	"github.com/gorkaio/gboy/pkg/cpu"
+)
+
+type benchMemory [0x10000]byte
+
+func (m *benchMemory) Read(address uint16) byte {
+	return m[address]
+}
+
+func (m *benchMemory) Write(address uint16, data byte) {
+	m[address] = data
+}
+
+func benchmarkStep(b *testing.B, instruction ...byte) {
+	mem := &benchMemory{}
+	copy(mem[0x100:], instruction)
+	c := cpu.New(mem)
+	state := c.Status()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.SetStatus(state)
+		if _, err := c.Step(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkStepNOP(b *testing.B) {
+	benchmarkStep(b, 0x00)
+}
+
+func BenchmarkStepLoadImmediateByte(b *testing.B) {
+	benchmarkStep(b, 0x06, 0x12)
+}
+
+func BenchmarkStepLoadImmediateWord(b *testing.B) {
+	benchmarkStep(b, 0x01, 0x34, 0x12)
+}
+
+func BenchmarkStepCBPrefixed(b *testing.B) {
+	benchmarkStep(b, 0xCB, 0x37)
+}
+
+func TestStepDoesNotAllocate(t *testing.T) {
+	mem := &benchMemory{}
+	copy(mem[0x100:], []byte{0x01, 0x34, 0x12})
+	c := cpu.New(mem)
+	state := c.Status()
+
+	allocs := testing.AllocsPerRun(100, func() {
+		c.SetStatus(state)
+		c.Step()
+	})
+	if allocs != 0 {
+		t.Errorf("Step allocated %v times per instruction", allocs)
+	}
+}
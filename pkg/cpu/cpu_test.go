@@ -25,9 +25,6 @@ func TestExecutesInstructions(t *testing.T) {
 
 	mem := mocks.NewMockMemory(ctrl)
 	mem.EXPECT().Read(PCStartAddress).Return(byte(0x00))
-	mem.EXPECT().Read(PCStartAddress + 1).Return(byte(0x00))
-	mem.EXPECT().Read(PCStartAddress + 2).Return(byte(0x00))
-	mem.EXPECT().Read(PCStartAddress + 3).Return(byte(0x00))
 
 	c := cpu.New(mem)
 	c.DebugDisable()
@@ -39,20 +36,76 @@ func TestExecutesInstructions(t *testing.T) {
 	assert.True(t, c.Status().PC > pc)
 }
 
-func TestErrorsWithUnknownOpcodes(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
+func TestLocksUpOnIllegalOpcodes(t *testing.T) {
+	for _, opCode := range []byte{0xD3, 0xDB, 0xDD, 0xE3, 0xE4, 0xEB, 0xEC, 0xED, 0xF4, 0xFC, 0xFD} {
+		ctrl := gomock.NewController(t)
+		mem := mocks.NewMockMemory(ctrl)
+		mem.EXPECT().Read(PCStartAddress).Return(opCode)
 
-	mem := mocks.NewMockMemory(ctrl)
-	mem.EXPECT().Read(PCStartAddress).Return(byte(0xDB))
-	mem.EXPECT().Read(PCStartAddress + 1).Return(byte(0x00))
-	mem.EXPECT().Read(PCStartAddress + 2).Return(byte(0x00))
-	mem.EXPECT().Read(PCStartAddress + 3).Return(byte(0x00))
+		c := cpu.New(mem)
+		c.DebugDisable()
+		_, err := c.Step()
+		assert.Equal(t, &cpu.IllegalOpcodeError{PC: PCStartAddress, Opcode: opCode, Bank: 0}, err)
+		assert.True(t, c.Status().Locked)
+
+		cycles, err := c.Step()
+		assert.NoError(t, err, "Locked CPU does not fetch further instructions")
+		assert.Equal(t, 4, cycles)
+		ctrl.Finish()
+	}
+}
+
+// bankedBenchMemory maps bank 0x20 at 0x0000-0x3FFF and bank 5 at 0x4000-0x7FFF
+type bankedBenchMemory struct {
+	benchMemory
+}
 
+func (m *bankedBenchMemory) ROMBankAt(address uint16) int {
+	switch {
+	case address < 0x4000:
+		return 0x20
+	case address < 0x8000:
+		return 5
+	}
+	return 0
+}
+
+func TestIllegalOpcodeErrorReportsROMBank(t *testing.T) {
+	mem := &bankedBenchMemory{}
+	mem.benchMemory[0x4321] = 0xFD
 	c := cpu.New(mem)
-	c.DebugDisable()
+	state := c.Status()
+	state.PC = 0x4321
+	c.SetStatus(state)
+
 	_, err := c.Step()
-	assert.Error(t, err, "Unknown opcode 0xDB")
+	assert.EqualError(t, err, "Illegal opcode 0xfd at 05:0x4321, CPU locked up")
+	illegal, ok := err.(*cpu.IllegalOpcodeError)
+	assert.True(t, ok)
+	assert.Equal(t, 5, illegal.Bank)
+}
+
+func TestIllegalOpcodeErrorAsksMemoryForTheBank(t *testing.T) {
+	tests := []struct {
+		pc   uint16
+		bank int
+	}{
+		{0x0321, 0x20},
+		{0x7FFF, 5},
+		{0xC000, 0},
+	}
+
+	for _, test := range tests {
+		mem := &bankedBenchMemory{}
+		mem.benchMemory[test.pc] = 0xFD
+		c := cpu.New(mem)
+		state := c.Status()
+		state.PC = test.pc
+		c.SetStatus(state)
+
+		_, err := c.Step()
+		assert.Equal(t, &cpu.IllegalOpcodeError{PC: test.pc, Opcode: 0xFD, Bank: test.bank}, err)
+	}
 }
 
 func TestExecutesNOP(t *testing.T) {
@@ -60,9 +113,6 @@ func TestExecutesNOP(t *testing.T) {
 	defer ctrl.Finish()
 	mem := mocks.NewMockMemory(ctrl)
 	mem.EXPECT().Read(PCStartAddress).Return(uint8(0x00))
-	mem.EXPECT().Read(PCStartAddress + 1).Return(uint8(0x00))
-	mem.EXPECT().Read(PCStartAddress + 2).Return(uint8(0x00))
-	mem.EXPECT().Read(PCStartAddress + 3).Return(uint8(0x00))
 
 	c := cpu.New(mem)
 	c.DebugDisable()
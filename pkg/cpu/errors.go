@@ -0,0 +1,26 @@
+package cpu
+
+import "fmt"
+
+// IllegalOpcodeError is returned when the CPU fetches an opcode that locks up the hardware
+type IllegalOpcodeError struct {
+	PC     uint16
+	Opcode byte
+	Bank   int
+}
+
+func (e *IllegalOpcodeError) Error() string {
+	return fmt.Sprintf("Illegal opcode %#02x at %02x:%#04x, CPU locked up", e.Opcode, e.Bank, e.PC)
+}
+
+type bankedMemory interface {
+	ROMBankAt(address uint16) int
+}
+
+func (cpu *CPU) illegalOpcode(address uint16, opCode byte) error {
+	bank := 0
+	if banked, ok := cpu.memory.(bankedMemory); ok {
+		bank = banked.ROMBankAt(address)
+	}
+	return &IllegalOpcodeError{PC: address, Opcode: opCode, Bank: bank}
+}
@@ -7,8 +7,12 @@ func (cpu *CPU) jump(a16 uint16) {
 }
 
 func (cpu *CPU) push(v uint16) {
-	cpu.SP.DecBy(2)
-	cpu.memoryWriteWord(cpu.SP.Get(), v)
+	h, l := bits.SplitWord(v)
+	cpu.tick()
+	cpu.SP.Dec()
+	cpu.memoryWriteByte(cpu.SP.Get(), h)
+	cpu.SP.Dec()
+	cpu.memoryWriteByte(cpu.SP.Get(), l)
 }
 
 func (cpu *CPU) pop() uint16 {
@@ -58,6 +62,15 @@ func (cpu *CPU) addWord(op uint16, value uint16, carryBit bool) (uint16, byte) {
 	return result, flags
 }
 
+// Flags come from the unsigned addition of r8 to the low byte of op
+func (cpu *CPU) addSigned(op uint16, r8 int8) (uint16, byte) {
+	_, l := bits.SplitWord(op)
+	d8 := byte(r8)
+	result := op + uint16(int16(r8))
+	flags := buildFlags(false, false, bits.HalfCarryAddByte(l, d8), bits.CarryAddByte(l, d8))
+	return result, flags
+}
+
 func (cpu *CPU) and(op byte, value byte) (byte, byte) {
 	result := op & value
 	flags := buildFlags(result == 0, false, true, false)
@@ -79,4 +92,92 @@ func (cpu *CPU) xor(op byte, value byte) (byte, byte) {
 func (cpu *CPU) cmp(op byte, value byte) byte {
 	diff := op - value
 	return buildFlags(diff == 0, true, bits.HalfCarrySubByte(op, value), bits.CarrySubByte(op, value))
-}
\ No newline at end of file
+}
+
+func (cpu *CPU) rlc(value byte) (byte, byte) {
+	carry := bits.BitOfByte(value, 7)
+	result := value<<1 | value>>7
+	flags := buildFlags(result == 0, false, false, carry)
+	return result, flags
+}
+
+func (cpu *CPU) rrc(value byte) (byte, byte) {
+	carry := bits.BitOfByte(value, 0)
+	result := value>>1 | value<<7
+	flags := buildFlags(result == 0, false, false, carry)
+	return result, flags
+}
+
+func (cpu *CPU) rl(value byte, carryBit bool) (byte, byte) {
+	carry := bits.BitOfByte(value, 7)
+	result := value << 1
+	if carryBit {
+		result |= 0x01
+	}
+	flags := buildFlags(result == 0, false, false, carry)
+	return result, flags
+}
+
+func (cpu *CPU) rr(value byte, carryBit bool) (byte, byte) {
+	carry := bits.BitOfByte(value, 0)
+	result := value >> 1
+	if carryBit {
+		result |= 0x80
+	}
+	flags := buildFlags(result == 0, false, false, carry)
+	return result, flags
+}
+
+func (cpu *CPU) sla(value byte) (byte, byte) {
+	carry := bits.BitOfByte(value, 7)
+	result := value << 1
+	flags := buildFlags(result == 0, false, false, carry)
+	return result, flags
+}
+
+func (cpu *CPU) sra(value byte) (byte, byte) {
+	carry := bits.BitOfByte(value, 0)
+	result := (value >> 1) | (value & 0x80)
+	flags := buildFlags(result == 0, false, false, carry)
+	return result, flags
+}
+
+func (cpu *CPU) srl(value byte) (byte, byte) {
+	carry := bits.BitOfByte(value, 0)
+	result := value >> 1
+	flags := buildFlags(result == 0, false, false, carry)
+	return result, flags
+}
+
+func (cpu *CPU) swap(value byte) (byte, byte) {
+	result := value<<4 | value>>4
+	flags := buildFlags(result == 0, false, false, false)
+	return result, flags
+}
+
+func (cpu *CPU) bit(value byte, bit uint8, carryBit bool) byte {
+	return buildFlags(!bits.BitOfByte(value, bit), false, true, carryBit)
+}
+
+func (cpu *CPU) daa(value byte, subtract, halfCarry, carryBit bool) (byte, byte) {
+	result := value
+	carry := carryBit
+	if subtract {
+		if carryBit {
+			result -= 0x60
+		}
+		if halfCarry {
+			result -= 0x06
+		}
+	} else {
+		if carryBit || value > 0x99 {
+			result += 0x60
+			carry = true
+		}
+		if halfCarry || value&0x0F > 0x09 {
+			result += 0x06
+		}
+	}
+	flags := buildFlags(result == 0, subtract, false, carry)
+	return result, flags
+}
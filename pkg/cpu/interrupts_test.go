@@ -0,0 +1,139 @@
+package cpu_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorkaio/gboy/pkg/cpu"
+	mocks "github.com/gorkaio/gboy/pkg/cpu/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServicesInterruptsInPriorityOrder(t *testing.T) {
+	tests := []struct {
+		ie, ifr   byte
+		vector    int
+		ifWritten byte
+	}{
+		{0x1F, 0xFF, 0x40, 0xFE},
+		{0x1F, 0xE2, 0x48, 0xE0},
+		{0x1F, 0xEC, 0x50, 0xE8},
+		{0x1E, 0xE9, 0x58, 0xE1},
+		{0x10, 0xFF, 0x60, 0xEF},
+	}
+
+	for _, test := range tests {
+		testCase := NewTestCase([]byte{})
+		testCase.WithDescription(fmt.Sprintf("IE=%#02x and IF=%#02x dispatches to %#02x", test.ie, test.ifr, test.vector))
+		testCase.WithRegister("PC", 0x1234)
+		testCase.WithRegister("SP", 0xFFFE)
+		testCase.WithIME(true)
+		testCase.ExpectIME(false)
+		testCase.ExpectRegister("PC", test.vector)
+		testCase.ExpectRegister("SP", 0xFFFC)
+		testCase.ExpectMemoryRead(0xFFFF, test.ie)
+		testCase.ExpectMemoryRead(0xFF0F, test.ifr)
+		testCase.ExpectMemoryWrite(0xFF0F, test.ifWritten)
+		testCase.ExpectMemoryWrite(0xFFFD, 0x12)
+		testCase.ExpectMemoryWrite(0xFFFC, 0x34)
+		testCase.ExpectCycles(20)
+		testCase.Run(t)
+	}
+}
+
+func TestDoesNotServiceInterruptsWhenNoneEnabled(t *testing.T) {
+	testDescription := testDescription{
+		"Requested but disabled interrupts are not serviced",
+		opcode{0x00},
+		regMap{},
+		regMap{},
+		memMap{0xFFFF: 0x00, 0xFF0F: 0xFF},
+		memMap{},
+		4,
+	}
+	testCase := buildTestCase(testDescription)
+	testCase.WithIME(true)
+	testCase.Run(t)
+}
+
+func TestDoesNotServiceInterruptsWhenIMEClear(t *testing.T) {
+	testDescription := testDescription{
+		"Pending interrupts are not serviced with IME clear",
+		opcode{0x00},
+		regMap{},
+		regMap{},
+		memMap{},
+		memMap{},
+		4,
+	}
+	testCase := buildTestCase(testDescription)
+	testCase.WithIME(false)
+	testCase.Run(t)
+}
+
+func newRAMBackedCPU(t *testing.T, ram map[uint16]byte) (*cpu.CPU, *gomock.Controller) {
+	ctrl := gomock.NewController(t)
+	mem := mocks.NewMockMemory(ctrl)
+	mem.EXPECT().Read(gomock.Any()).DoAndReturn(func(address uint16) byte {
+		return ram[address]
+	}).AnyTimes()
+	mem.EXPECT().Write(gomock.Any(), gomock.Any()).Do(func(address uint16, data byte) {
+		ram[address] = data
+	}).AnyTimes()
+	return cpu.New(mem), ctrl
+}
+
+func TestInterruptIsServicedOneInstructionAfterEI(t *testing.T) {
+	ram := map[uint16]byte{
+		0x100:  0xFB, // EI
+		0x101:  0x04, // INC B
+		0x102:  0x04, // INC B
+		0xFFFF: 0x04,
+		0xFF0F: 0x04,
+	}
+	c, ctrl := newRAMBackedCPU(t, ram)
+	defer ctrl.Finish()
+
+	for i := 0; i < 2; i++ {
+		_, err := c.Step()
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, uint16(0x102), c.Status().PC)
+	assert.Equal(t, uint16(0x0100), c.Status().BC)
+	assert.True(t, c.Status().IME)
+
+	cycles, err := c.Step()
+	assert.NoError(t, err)
+	assert.Equal(t, 20, cycles)
+	assert.Equal(t, uint16(0x50), c.Status().PC)
+	assert.False(t, c.Status().IME)
+	assert.Equal(t, byte(0x00), ram[0xFF0F])
+	assert.Equal(t, byte(0x01), ram[0xFFFD])
+	assert.Equal(t, byte(0x02), ram[0xFFFC])
+}
+
+func TestHaltedCPUServicesInterruptOnWakeUp(t *testing.T) {
+	ram := map[uint16]byte{
+		0x100:  0x76, // HALT
+		0xFFFF: 0x01,
+	}
+	c, ctrl := newRAMBackedCPU(t, ram)
+	defer ctrl.Finish()
+	c.EnableInterrupts()
+
+	for i := 0; i < 3; i++ {
+		_, err := c.Step()
+		assert.NoError(t, err)
+	}
+	assert.True(t, c.Status().Halted)
+
+	ram[0xFF0F] = 0x01
+	cycles, err := c.Step()
+	assert.NoError(t, err)
+	assert.Equal(t, 24, cycles, "Waking up takes a machine cycle before the dispatch")
+	assert.False(t, c.Status().Halted)
+	assert.Equal(t, uint16(0x40), c.Status().PC)
+	assert.Equal(t, byte(0x01), ram[0xFFFD])
+	assert.Equal(t, byte(0x01), ram[0xFFFC])
+}
@@ -7,513 +7,574 @@ import (
 )
 
 const (
+	lnone = 0
 	lbyte = 1
 	lword = 2
 )
 
-type opHandler func(cpu *CPU, args ...int) int
+type opHandler func(cpu *CPU, arg int) int
 
 type op struct {
-	mnemonic string
-	args     []int
-	length   uint16
-	handler  opHandler
+	*opDefinition
+	arg int
 }
 
 func (op *op) String() string {
-	args := make([]interface{}, len(op.args))
-	for i, arg := range op.args {
-		args[i] = arg
+	if op.argLength == lnone {
+		return op.mnemonic
 	}
-
-	return fmt.Sprintf(op.mnemonic, args...)
+	return fmt.Sprintf(op.mnemonic, op.arg)
 }
 
 type opDefinition struct {
-	mnemonic   string
-	length     uint16
-	argLengths []int
-	handler    opHandler
+	mnemonic  string
+	length    uint16
+	argLength int
+	handler   opHandler
 }
 
-var opDefinitions = map[uint8]opDefinition{
+var opDefinitions = [256]opDefinition{
 	0x00: {
-		mnemonic:   "NOP",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "NOP",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.nop()
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x01: {
-		mnemonic:   "LD BC, %#04x",
-		argLengths: []int{lword},
-		length:     3,
-		handler: func(cpu *CPU, args ...int) int {
-			cycles := cpu.ldR16d16(cpu.BC, uint16(args[0]))
+		mnemonic:  "LD BC, %#04x",
+		argLength: lword,
+		length:    3,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.ldR16d16(cpu.BC, uint16(arg))
 			cpu.PC.IncBy(3)
 			return cycles
 		},
 	},
 	0x02: {
-		mnemonic:   "LD (BC), A",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD (BC), A",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldaR16R8(cpu.BC, cpu.A)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x03: {
-		mnemonic:   "INC BC",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "INC BC",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.incR16(cpu.BC)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x04: {
-		mnemonic:   "INC B",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "INC B",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.incR8(cpu.B)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x05: {
-		mnemonic:   "DEC B",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "DEC B",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.decR8(cpu.B)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x06: {
-		mnemonic:   "LD B, %#02x",
-		argLengths: []int{lbyte},
-		length:     2,
-		handler: func(cpu *CPU, args ...int) int {
-			cycles := cpu.ldR8d8(cpu.B, uint8(args[0]))
+		mnemonic:  "LD B, %#02x",
+		argLength: lbyte,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.ldR8d8(cpu.B, uint8(arg))
 			cpu.PC.IncBy(2)
 			return cycles
 		},
 	},
 	0x07: {
-		mnemonic:   "RLCA",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
-			cpu.F.Set(0)
-			cpu.SetFlagC(bits.BitOfByte(cpu.A.Get(), 7))
-			cpu.A.Set(cpu.A.Get() << 1)
+		mnemonic:  "RLCA",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
+			result, flags := cpu.rlc(cpu.A.Get())
+			cpu.A.Set(result)
+			cpu.F.Set(flags &^ flagZ)
 			cpu.PC.Inc()
 			return 4
 		},
 	},
 	0x08: {
-		mnemonic:   "LD (%#04x), SP",
-		argLengths: []int{lword},
-		length:     3,
-		handler: func(cpu *CPU, args ...int) int {
-			cycles := cpu.lda16R16(uint16(args[0]), cpu.SP)
+		mnemonic:  "LD (%#04x), SP",
+		argLength: lword,
+		length:    3,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.lda16R16(uint16(arg), cpu.SP)
 			cpu.PC.IncBy(3)
 			return cycles
 		},
 	},
 	0x09: {
-		mnemonic:   "ADD HL, BC",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "ADD HL, BC",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.addR16R16(cpu.HL, cpu.BC)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x0A: {
-		mnemonic:   "LD A, (BC)",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD A, (BC)",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8aR16(cpu.A, cpu.BC)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x0B: {
-		mnemonic:   "DEC BC",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "DEC BC",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.decR16(cpu.BC)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x0C: {
-		mnemonic:   "INC C",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "INC C",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.incR8(cpu.C)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x0D: {
-		mnemonic:   "DEC C",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "DEC C",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.decR8(cpu.C)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x0E: {
-		mnemonic:   "LD C, %#02x",
-		argLengths: []int{lbyte},
-		length:     2,
-		handler: func(cpu *CPU, args ...int) int {
-			cycles := cpu.ldR8d8(cpu.C, uint8(args[0]))
+		mnemonic:  "LD C, %#02x",
+		argLength: lbyte,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.ldR8d8(cpu.C, uint8(arg))
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x0F: {
+		mnemonic:  "RRCA",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
+			result, flags := cpu.rrc(cpu.A.Get())
+			cpu.A.Set(result)
+			cpu.F.Set(flags &^ flagZ)
+			cpu.PC.Inc()
+			return 4
+		},
+	},
+	0x10: {
+		mnemonic:  "STOP",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.stop()
 			cpu.PC.IncBy(2)
 			return cycles
 		},
 	},
-	/* TODO: 0x0F */
-	/* TODO: 0x10 */
 	0x11: {
-		mnemonic:   "LD DE, %#04x",
-		argLengths: []int{lword},
-		length:     3,
-		handler: func(cpu *CPU, args ...int) int {
-			cycles := cpu.ldR16d16(cpu.DE, uint16(args[0]))
+		mnemonic:  "LD DE, %#04x",
+		argLength: lword,
+		length:    3,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.ldR16d16(cpu.DE, uint16(arg))
 			cpu.PC.IncBy(3)
 			return cycles
 		},
 	},
 	0x12: {
-		mnemonic:   "LD (DE), A",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD (DE), A",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldaR16R8(cpu.DE, cpu.A)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x13: {
-		mnemonic:   "INC DE",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "INC DE",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.incR16(cpu.DE)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x14: {
-		mnemonic:   "INC D",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "INC D",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.incR8(cpu.D)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x15: {
-		mnemonic:   "DEC D",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "DEC D",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.decR8(cpu.D)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x16: {
-		mnemonic:   "LD D, %#02x",
-		argLengths: []int{lbyte},
-		length:     2,
-		handler: func(cpu *CPU, args ...int) int {
-			cycles := cpu.ldR8d8(cpu.D, uint8(args[0]))
+		mnemonic:  "LD D, %#02x",
+		argLength: lbyte,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.ldR8d8(cpu.D, uint8(arg))
 			cpu.PC.IncBy(2)
 			return cycles
 		},
 	},
-	/* TODO: 0x17 */
+	0x17: {
+		mnemonic:  "RLA",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
+			result, flags := cpu.rl(cpu.A.Get(), cpu.FlagC())
+			cpu.A.Set(result)
+			cpu.F.Set(flags &^ flagZ)
+			cpu.PC.Inc()
+			return 4
+		},
+	},
 	0x18: {
-		mnemonic:   "JR %#02x",
-		argLengths: []int{lbyte},
-		length:     2,
-		handler: func(cpu *CPU, args ...int) int {
-			return cpu.jr(int8(args[0]))
+		mnemonic:  "JR %#02x",
+		argLength: lbyte,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			return cpu.jr(int8(arg))
 		},
 	},
 	0x19: {
-		mnemonic:   "ADD HL, DE",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "ADD HL, DE",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.addR16R16(cpu.HL, cpu.DE)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x1A: {
-		mnemonic:   "LD A, (DE)",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD A, (DE)",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8aR16(cpu.A, cpu.DE)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x1B: {
-		mnemonic:   "DEC DE",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "DEC DE",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.decR16(cpu.DE)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x1C: {
-		mnemonic:   "INC E",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "INC E",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.incR8(cpu.E)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x1D: {
-		mnemonic:   "DEC E",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "DEC E",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.decR8(cpu.E)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x1E: {
-		mnemonic:   "LD E, %#02x",
-		argLengths: []int{lbyte},
-		length:     2,
-		handler: func(cpu *CPU, args ...int) int {
-			cycles := cpu.ldR8d8(cpu.E, uint8(args[0]))
+		mnemonic:  "LD E, %#02x",
+		argLength: lbyte,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.ldR8d8(cpu.E, uint8(arg))
 			cpu.PC.IncBy(2)
 			return cycles
 		},
 	},
-	/* TODO: 0x1F */
+	0x1F: {
+		mnemonic:  "RRA",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
+			result, flags := cpu.rr(cpu.A.Get(), cpu.FlagC())
+			cpu.A.Set(result)
+			cpu.F.Set(flags &^ flagZ)
+			cpu.PC.Inc()
+			return 4
+		},
+	},
 	0x20: {
-		mnemonic:   "JR NZ, %#02x",
-		argLengths: []int{lbyte},
-		length:     2,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "JR NZ, %#02x",
+		argLength: lbyte,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
 			if !cpu.FlagZ() {
-				return cpu.jr(int8(args[0]))
+				return cpu.jr(int8(arg))
 			}
 			cpu.PC.IncBy(2)
 			return 8
 		},
 	},
 	0x21: {
-		mnemonic:   "LD HL, %#04x",
-		argLengths: []int{lword},
-		length:     3,
-		handler: func(cpu *CPU, args ...int) int {
-			cycles := cpu.ldR16d16(cpu.HL, uint16(args[0]))
+		mnemonic:  "LD HL, %#04x",
+		argLength: lword,
+		length:    3,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.ldR16d16(cpu.HL, uint16(arg))
 			cpu.PC.IncBy(3)
 			return cycles
 		},
 	},
 	0x22: {
-		mnemonic:   "LDI (HL), A",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LDI (HL), A",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldiaR16R8(cpu.HL, cpu.A)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x23: {
-		mnemonic:   "INC HL",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
-			cycles :=  cpu.incR16(cpu.HL)
+		mnemonic:  "INC HL",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.incR16(cpu.HL)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x24: {
-		mnemonic:   "INC H",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "INC H",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.incR8(cpu.H)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x25: {
-		mnemonic:   "DEC H",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "DEC H",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.decR8(cpu.H)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x26: {
-		mnemonic:   "LD H, %#02x",
-		argLengths: []int{lbyte},
-		length:     2,
-		handler: func(cpu *CPU, args ...int) int {
-			cycles := cpu.ldR8d8(cpu.H, uint8(args[0]))
+		mnemonic:  "LD H, %#02x",
+		argLength: lbyte,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.ldR8d8(cpu.H, uint8(arg))
 			cpu.PC.IncBy(2)
 			return cycles
 		},
 	},
-	/* TODO: 0x27 */
+	0x27: {
+		mnemonic:  "DAA",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
+			result, flags := cpu.daa(cpu.A.Get(), cpu.FlagN(), cpu.FlagH(), cpu.FlagC())
+			cpu.A.Set(result)
+			cpu.F.Set(flags)
+			cpu.PC.Inc()
+			return 4
+		},
+	},
 	0x28: {
-		mnemonic:   "JR Z, %#02x",
-		argLengths: []int{lbyte},
-		length:     2,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "JR Z, %#02x",
+		argLength: lbyte,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
 			if cpu.FlagZ() {
-				return cpu.jr(int8(args[0]))
+				return cpu.jr(int8(arg))
 			}
 			cpu.PC.IncBy(2)
 			return 8
 		},
 	},
 	0x29: {
-		mnemonic:   "ADD HL, HL",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "ADD HL, HL",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.addR16R16(cpu.HL, cpu.HL)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x2A: {
-		mnemonic:   "LDI A, (HL)",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LDI A, (HL)",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldiR8aR16(cpu.A, cpu.HL)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x2B: {
-		mnemonic:   "DEC HL",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "DEC HL",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.decR16(cpu.HL)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x2C: {
-		mnemonic:   "INC L",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "INC L",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.incR8(cpu.L)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x2D: {
-		mnemonic:   "DEC L",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "DEC L",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.decR8(cpu.L)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x2E: {
-		mnemonic:   "LD L, %#02x",
-		argLengths: []int{lbyte},
-		length:     2,
-		handler: func(cpu *CPU, args ...int) int {
-			cycles := cpu.ldR8d8(cpu.L, uint8(args[0]))
+		mnemonic:  "LD L, %#02x",
+		argLength: lbyte,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.ldR8d8(cpu.L, uint8(arg))
 			cpu.PC.IncBy(2)
 			return cycles
 		},
 	},
-	/* TODO: 0x2F */
+	0x2F: {
+		mnemonic:  "CPL",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
+			cpu.A.Set(^cpu.A.Get())
+			cpu.SetFlagN(true)
+			cpu.SetFlagH(true)
+			cpu.PC.Inc()
+			return 4
+		},
+	},
 	0x30: {
-		mnemonic:   "JR NC, %#02x",
-		argLengths: []int{lbyte},
-		length:     2,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "JR NC, %#02x",
+		argLength: lbyte,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
 			if !cpu.FlagC() {
-				return cpu.jr(int8(args[0]))
+				return cpu.jr(int8(arg))
 			}
 			cpu.PC.IncBy(2)
 			return 8
 		},
 	},
 	0x31: {
-		mnemonic:   "LD SP, %#04x",
-		argLengths: []int{lword},
-		length:     3,
-		handler: func(cpu *CPU, args ...int) int {
-			cycles := cpu.ldR16d16(cpu.SP, uint16(args[0]))
+		mnemonic:  "LD SP, %#04x",
+		argLength: lword,
+		length:    3,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.ldR16d16(cpu.SP, uint16(arg))
 			cpu.PC.IncBy(3)
 			return cycles
 		},
 	},
 	0x32: {
-		mnemonic:   "LDD (HL), A",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LDD (HL), A",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.lddaR16R8(cpu.HL, cpu.A)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x33: {
-		mnemonic:   "INC SP",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "INC SP",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.incR16(cpu.SP)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x34: {
-		mnemonic:   "INC (HL)",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "INC (HL)",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			a := cpu.HL.Get()
 			d8 := cpu.memoryReadByte(cpu.HL.Get())
 			result := d8 + 1
@@ -527,10 +588,10 @@ var opDefinitions = map[uint8]opDefinition{
 		},
 	},
 	0x35: {
-		mnemonic:   "DEC (HL)",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "DEC (HL)",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			a := cpu.HL.Get()
 			d8 := cpu.memoryReadByte(cpu.HL.Get())
 			result := d8 - 1
@@ -544,1367 +605,1398 @@ var opDefinitions = map[uint8]opDefinition{
 		},
 	},
 	0x36: {
-		mnemonic:   "LD (HL), %#02x",
-		argLengths: []int{lbyte},
-		length:     2,
-		handler: func(cpu *CPU, args ...int) int {
-			d8 := byte(args[0])
+		mnemonic:  "LD (HL), %#02x",
+		argLength: lbyte,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			d8 := byte(arg)
 			cycles := cpu.ldaR16d8(cpu.HL, d8)
 			cpu.PC.IncBy(2)
 			return cycles
 		},
 	},
-	/* TODO: 0x37 */
+	0x37: {
+		mnemonic:  "SCF",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
+			cpu.SetFlagN(false)
+			cpu.SetFlagH(false)
+			cpu.SetFlagC(true)
+			cpu.PC.Inc()
+			return 4
+		},
+	},
 	0x38: {
-		mnemonic:   "JR C, %#02x",
-		argLengths: []int{lbyte},
-		length:     2,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "JR C, %#02x",
+		argLength: lbyte,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
 			if cpu.FlagC() {
-				return cpu.jr(int8(args[0]))
+				return cpu.jr(int8(arg))
 			}
 			cpu.PC.IncBy(2)
 			return 8
 		},
 	},
 	0x39: {
-		mnemonic:   "ADD HL, SP",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "ADD HL, SP",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.addR16R16(cpu.HL, cpu.SP)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x3A: {
-		mnemonic:   "LDD A, (HL)",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LDD A, (HL)",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.lddR8aR16(cpu.A, cpu.HL)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x3B: {
-		mnemonic:   "DEC SP",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "DEC SP",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.decR16(cpu.SP)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x3C: {
-		mnemonic:   "INC A",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "INC A",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.incR8(cpu.A)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x3D: {
-		mnemonic:   "DEC A",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "DEC A",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.decR8(cpu.A)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x3E: {
-		mnemonic:   "LD A, %#02x",
-		argLengths: []int{lbyte},
-		length:     2,
-		handler: func(cpu *CPU, args ...int) int {
-			d8 := byte(args[0])
+		mnemonic:  "LD A, %#02x",
+		argLength: lbyte,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			d8 := byte(arg)
 			cycles := cpu.ldR8d8(cpu.A, d8)
 			cpu.PC.IncBy(2)
 			return cycles
 		},
 	},
+	0x3F: {
+		mnemonic:  "CCF",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
+			cpu.SetFlagN(false)
+			cpu.SetFlagH(false)
+			cpu.SetFlagC(!cpu.FlagC())
+			cpu.PC.Inc()
+			return 4
+		},
+	},
 	0x40: {
-		mnemonic:   "LD B, B",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD B, B",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.B, cpu.B)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x41: {
-		mnemonic:   "LD B, C",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD B, C",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.B, cpu.C)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x42: {
-		mnemonic:   "LD B, D",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD B, D",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.B, cpu.D)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x43: {
-		mnemonic:   "LD B, E",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD B, E",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.B, cpu.E)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x44: {
-		mnemonic:   "LD B, H",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD B, H",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.B, cpu.H)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x45: {
-		mnemonic:   "LD B, L",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD B, L",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.B, cpu.L)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x46: {
-		mnemonic:   "LD B, (HL)",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD B, (HL)",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8aR16(cpu.B, cpu.HL)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x47: {
-		mnemonic:   "LD B, A",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD B, A",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.B, cpu.A)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x48: {
-		mnemonic:   "LD C, B",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD C, B",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.C, cpu.B)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x49: {
-		mnemonic:   "LD C, C",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD C, C",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.C, cpu.C)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x4A: {
-		mnemonic:   "LD C, D",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD C, D",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.C, cpu.D)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x4B: {
-		mnemonic:   "LD C, E",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD C, E",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.C, cpu.E)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x4C: {
-		mnemonic:   "LD C, H",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD C, H",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.C, cpu.H)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x4D: {
-		mnemonic:   "LD C, L",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD C, L",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.C, cpu.L)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x4E: {
-		mnemonic:   "LD C, (HL)",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD C, (HL)",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8aR16(cpu.C, cpu.HL)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x4F: {
-		mnemonic:   "LD C, A",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD C, A",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.C, cpu.A)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x50: {
-		mnemonic:   "LD D, B",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD D, B",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.D, cpu.B)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x51: {
-		mnemonic:   "LD D, C",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD D, C",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.D, cpu.C)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x52: {
-		mnemonic:   "LD D, D",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD D, D",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.D, cpu.D)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x53: {
-		mnemonic:   "LD D, E",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD D, E",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.D, cpu.E)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x54: {
-		mnemonic:   "LD D, H",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD D, H",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.D, cpu.H)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x55: {
-		mnemonic:   "LD D, L",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD D, L",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.D, cpu.L)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x56: {
-		mnemonic:   "LD D, (HL)",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD D, (HL)",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8aR16(cpu.D, cpu.HL)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x57: {
-		mnemonic:   "LD D, A",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD D, A",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.D, cpu.A)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x58: {
-		mnemonic:   "LD E, B",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD E, B",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.E, cpu.B)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x59: {
-		mnemonic:   "LD E, C",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD E, C",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.E, cpu.C)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x5A: {
-		mnemonic:   "LD E, D",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD E, D",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.E, cpu.D)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x5B: {
-		mnemonic:   "LD E, E",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD E, E",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.E, cpu.E)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x5C: {
-		mnemonic:   "LD E, H",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD E, H",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.E, cpu.H)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x5D: {
-		mnemonic:   "LD E, L",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD E, L",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.E, cpu.L)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x5E: {
-		mnemonic:   "LD E, (HL)",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD E, (HL)",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8aR16(cpu.E, cpu.HL)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x5F: {
-		mnemonic:   "LD E, A",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD E, A",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.E, cpu.A)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x60: {
-		mnemonic:   "LD H, B",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD H, B",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.H, cpu.B)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x61: {
-		mnemonic:   "LD H, C",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD H, C",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.H, cpu.C)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x62: {
-		mnemonic:   "LD H, D",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD H, D",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.H, cpu.D)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x63: {
-		mnemonic:   "LD H, E",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD H, E",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.H, cpu.E)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x64: {
-		mnemonic:   "LD H, H",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD H, H",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.H, cpu.H)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x65: {
-		mnemonic:   "LD H, L",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD H, L",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.H, cpu.L)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x66: {
-		mnemonic:   "LD H, (HL)",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD H, (HL)",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8aR16(cpu.H, cpu.HL)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x67: {
-		mnemonic:   "LD H, A",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD H, A",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.H, cpu.A)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x68: {
-		mnemonic:   "LD L, B",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD L, B",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.L, cpu.B)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x69: {
-		mnemonic:   "LD L, C",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD L, C",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.L, cpu.C)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x6A: {
-		mnemonic:   "LD L, D",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD L, D",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.L, cpu.D)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x6B: {
-		mnemonic:   "LD L, E",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD L, E",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.L, cpu.E)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x6C: {
-		mnemonic:   "LD L, H",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD L, H",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.L, cpu.H)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x6D: {
-		mnemonic:   "LD L, L",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD L, L",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.L, cpu.L)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x6E: {
-		mnemonic:   "LD L, (HL)",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD L, (HL)",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8aR16(cpu.L, cpu.HL)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x6F: {
-		mnemonic:   "LD L, A",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD L, A",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.L, cpu.A)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x70: {
-		mnemonic:   "LD (HL), B",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD (HL), B",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldaR16R8(cpu.HL, cpu.B)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x71: {
-		mnemonic:   "LD (HL), C",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD (HL), C",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldaR16R8(cpu.HL, cpu.C)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x72: {
-		mnemonic:   "LD (HL), D",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD (HL), D",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldaR16R8(cpu.HL, cpu.D)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x73: {
-		mnemonic:   "LD (HL), E",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD (HL), E",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldaR16R8(cpu.HL, cpu.E)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x74: {
-		mnemonic:   "LD (HL), H",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD (HL), H",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldaR16R8(cpu.HL, cpu.H)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x75: {
-		mnemonic:   "LD (HL), L",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD (HL), L",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldaR16R8(cpu.HL, cpu.L)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
-	/* TODO: 0x76 */
+	0x76: {
+		mnemonic:  "HALT",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
+			cpu.PC.Inc()
+			return cpu.halt()
+		},
+	},
 	0x77: {
-		mnemonic:   "LD (HL), A",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD (HL), A",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldaR16R8(cpu.HL, cpu.A)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x78: {
-		mnemonic:   "LD A, B",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD A, B",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.A, cpu.B)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x79: {
-		mnemonic:   "LD A, C",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD A, C",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.A, cpu.C)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x7A: {
-		mnemonic:   "LD A, D",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD A, D",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.A, cpu.D)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x7B: {
-		mnemonic:   "LD A, E",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD A, E",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.A, cpu.E)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x7C: {
-		mnemonic:   "LD A, H",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD A, H",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.A, cpu.H)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x7D: {
-		mnemonic:   "LD A, L",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD A, L",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.A, cpu.L)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x7E: {
-		mnemonic:   "LD A, (HL)",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD A, (HL)",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8aR16(cpu.A, cpu.HL)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x7F: {
-		mnemonic:   "LD A, A",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD A, A",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8R8(cpu.A, cpu.A)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x80: {
-		mnemonic:   "ADD A, B",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "ADD A, B",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.addR8R8(cpu.A, cpu.B)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x81: {
-		mnemonic:   "ADD A, C",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "ADD A, C",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.addR8R8(cpu.A, cpu.C)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x82: {
-		mnemonic:   "ADD A, D",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "ADD A, D",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.addR8R8(cpu.A, cpu.D)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x83: {
-		mnemonic:   "ADD A, E",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "ADD A, E",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.addR8R8(cpu.A, cpu.E)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x84: {
-		mnemonic:   "ADD A, H",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "ADD A, H",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.addR8R8(cpu.A, cpu.H)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x85: {
-		mnemonic:   "ADD A, L",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "ADD A, L",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.addR8R8(cpu.A, cpu.L)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x86: {
-		mnemonic:   "ADD A, (HL)",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "ADD A, (HL)",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.addR8aR16(cpu.A, cpu.HL)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x87: {
-		mnemonic:   "ADD A, A",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "ADD A, A",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.addR8R8(cpu.A, cpu.A)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x88: {
-		mnemonic:   "ADC A, B",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "ADC A, B",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.adcR8R8(cpu.A, cpu.B)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x89: {
-		mnemonic:   "ADC A, C",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "ADC A, C",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.adcR8R8(cpu.A, cpu.C)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x8A: {
-		mnemonic:   "ADC A, D",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "ADC A, D",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.adcR8R8(cpu.A, cpu.D)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x8B: {
-		mnemonic:   "ADC A, E",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "ADC A, E",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.adcR8R8(cpu.A, cpu.E)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x8C: {
-		mnemonic:   "ADC A, H",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "ADC A, H",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.adcR8R8(cpu.A, cpu.H)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x8D: {
-		mnemonic:   "ADC A, L",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "ADC A, L",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.adcR8R8(cpu.A, cpu.L)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x8E: {
-		mnemonic:   "ADC A, (HL)",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "ADC A, (HL)",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.adcR8aR16(cpu.A, cpu.HL)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x8F: {
-		mnemonic:   "ADC A, A",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "ADC A, A",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.adcR8R8(cpu.A, cpu.A)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x90: {
-		mnemonic:   "SUB B",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "SUB B",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.subR8(cpu.B)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x91: {
-		mnemonic:   "SUB C",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "SUB C",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.subR8(cpu.C)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x92: {
-		mnemonic:   "SUB D",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "SUB D",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.subR8(cpu.D)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x93: {
-		mnemonic:   "SUB E",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "SUB E",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.subR8(cpu.E)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x94: {
-		mnemonic:   "SUB H",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "SUB H",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.subR8(cpu.H)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x95: {
-		mnemonic:   "SUB L",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "SUB L",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.subR8(cpu.L)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x96: {
-		mnemonic:   "SUB (HL)",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "SUB (HL)",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.subaR16(cpu.HL)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x97: {
-		mnemonic:   "SUB A",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "SUB A",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.subR8(cpu.A)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x98: {
-		mnemonic:   "SBC B",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "SBC B",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.sbcR8(cpu.B)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x99: {
-		mnemonic:   "SBC C",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "SBC C",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.sbcR8(cpu.C)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x9A: {
-		mnemonic:   "SBC D",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "SBC D",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.sbcR8(cpu.D)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x9B: {
-		mnemonic:   "SBC E",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "SBC E",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.sbcR8(cpu.E)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x9C: {
-		mnemonic:   "SBC H",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "SBC H",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.sbcR8(cpu.H)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x9D: {
-		mnemonic:   "SBC L",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "SBC L",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.sbcR8(cpu.L)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x9E: {
-		mnemonic:   "SBC (HL)",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "SBC (HL)",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.sbcR8aR16(cpu.A, cpu.HL)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0x9F: {
-		mnemonic:   "SBC A",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "SBC A",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.sbcR8(cpu.A)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xA0: {
-		mnemonic:   "AND B",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "AND B",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.andR8(cpu.B)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xA1: {
-		mnemonic:   "AND C",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "AND C",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.andR8(cpu.C)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xA2: {
-		mnemonic:   "AND D",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "AND D",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.andR8(cpu.D)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xA3: {
-		mnemonic:   "AND E",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "AND E",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.andR8(cpu.E)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xA4: {
-		mnemonic:   "AND H",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "AND H",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.andR8(cpu.H)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xA5: {
-		mnemonic:   "AND L",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "AND L",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.andR8(cpu.L)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xA6: {
-		mnemonic:   "AND (HL)",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "AND (HL)",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.andaR16(cpu.HL)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xA7: {
-		mnemonic:   "AND A",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "AND A",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.andR8(cpu.A)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xA8: {
-		mnemonic:   "XOR B",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "XOR B",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.xorR8(cpu.B)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xA9: {
-		mnemonic:   "XOR C",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "XOR C",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.xorR8(cpu.C)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xAA: {
-		mnemonic:   "XOR D",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "XOR D",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.xorR8(cpu.D)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xAB: {
-		mnemonic:   "XOR E",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "XOR E",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.xorR8(cpu.E)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xAC: {
-		mnemonic:   "XOR H",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "XOR H",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.xorR8(cpu.H)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xAD: {
-		mnemonic:   "XOR L",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "XOR L",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.xorR8(cpu.L)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xAE: {
-		mnemonic:   "XOR (HL)",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "XOR (HL)",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.xoraR16(cpu.HL)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xAF: {
-		mnemonic:   "XOR A",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "XOR A",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.xorR8(cpu.A)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xB0: {
-		mnemonic:   "OR B",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "OR B",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.orR8(cpu.B)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xB1: {
-		mnemonic:   "OR C",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "OR C",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.orR8(cpu.C)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xB2: {
-		mnemonic:   "OR D",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "OR D",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.orR8(cpu.D)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xB3: {
-		mnemonic:   "OR E",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "OR E",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.orR8(cpu.E)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xB4: {
-		mnemonic:   "OR H",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "OR H",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.orR8(cpu.H)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xB5: {
-		mnemonic:   "OR L",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "OR L",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.orR8(cpu.L)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xB6: {
-		mnemonic:   "OR (HL)",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "OR (HL)",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.oraR16(cpu.HL)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xB7: {
-		mnemonic:   "OR A",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "OR A",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.orR8(cpu.A)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xB8: {
-		mnemonic:   "CP B",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "CP B",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.cpR8(cpu.B)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xB9: {
-		mnemonic:   "CP C",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "CP C",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.cpR8(cpu.C)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xBA: {
-		mnemonic:   "CP D",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "CP D",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.cpR8(cpu.D)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xBB: {
-		mnemonic:   "CP E",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "CP E",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.cpR8(cpu.E)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xBC: {
-		mnemonic:   "CP H",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "CP H",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.cpR8(cpu.H)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xBD: {
-		mnemonic:   "CP L",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "CP L",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.cpR8(cpu.L)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xBE: {
-		mnemonic:   "CP (HL)",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "CP (HL)",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.cpA8(cpu.L)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xBF: {
-		mnemonic:   "CP A",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "CP A",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.cpR8(cpu.A)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xC0: {
-		mnemonic:   "RETNZ",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
-			if (cpu.FlagZ()) {
+		mnemonic:  "RETNZ",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
+			if cpu.FlagZ() {
 				cpu.PC.Inc()
 				return 8
 			}
@@ -1915,82 +2007,83 @@ var opDefinitions = map[uint8]opDefinition{
 		},
 	},
 	0xC1: {
-		mnemonic:   "POP BC",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "POP BC",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.popR16(cpu.BC)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xC2: {
-		mnemonic:   "JR NZ, %#04x",
-		argLengths: []int{lword},
-		length:     3,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "JR NZ, %#04x",
+		argLength: lword,
+		length:    3,
+		handler: func(cpu *CPU, arg int) int {
 			if !cpu.FlagZ() {
-				return cpu.jmp(uint16(args[0]))
+				return cpu.jmp(uint16(arg))
 			}
 			cpu.PC.IncBy(3)
 			return 12
 		},
 	},
 	0xC3: {
-		mnemonic:   "JMP %#04x",
-		argLengths: []int{lword},
-		length:     3,
-		handler: func(cpu *CPU, args ...int) int {
-			return cpu.jmp(uint16(args[0]))
+		mnemonic:  "JMP %#04x",
+		argLength: lword,
+		length:    3,
+		handler: func(cpu *CPU, arg int) int {
+			return cpu.jmp(uint16(arg))
 		},
 	},
 	0xC4: {
-		mnemonic: "CALL NZ, %#04x",
-		argLengths: []int{lword},
-		length: 3,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "CALL NZ, %#04x",
+		argLength: lword,
+		length:    3,
+		handler: func(cpu *CPU, arg int) int {
 			if cpu.FlagZ() {
 				cpu.PC.IncBy(3)
 				return 12
 			}
-			cpu.call(uint16(args[0]))
+			cpu.PC.IncBy(3)
+			cpu.call(uint16(arg))
 			return 24
 		},
 	},
 	0xC5: {
-		mnemonic:   "PUSH BC",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "PUSH BC",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.pushR16(cpu.BC)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xC6: {
-		mnemonic:   "ADD %#02x",
-		argLengths: []int{lbyte},
-		length:     2,
-		handler: func(cpu *CPU, args ...int) int {
-			cycles := cpu.addR8d8(cpu.A, byte(args[0]))
+		mnemonic:  "ADD %#02x",
+		argLength: lbyte,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.addR8d8(cpu.A, byte(arg))
 			cpu.PC.IncBy(2)
 			return cycles
 		},
 	},
 	0xC7: {
-		mnemonic:   "RST 00H",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "RST 00H",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			return cpu.rst(0x0000)
 		},
 	},
 	0xC8: {
-		mnemonic:   "RETZ",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
-			if (!cpu.FlagZ()) {
+		mnemonic:  "RETZ",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
+			if !cpu.FlagZ() {
 				cpu.PC.Inc()
 				return 8
 			}
@@ -2001,10 +2094,10 @@ var opDefinitions = map[uint8]opDefinition{
 		},
 	},
 	0xC9: {
-		mnemonic:   "RET",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "RET",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			addr := cpu.memoryReadWord(cpu.SP.Get())
 			cpu.PC.Set(addr)
 			cpu.SP.IncBy(2)
@@ -2012,64 +2105,65 @@ var opDefinitions = map[uint8]opDefinition{
 		},
 	},
 	0xCA: {
-		mnemonic:   "JP Z, %#04x",
-		argLengths: []int{lword},
-		length:     3,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "JP Z, %#04x",
+		argLength: lword,
+		length:    3,
+		handler: func(cpu *CPU, arg int) int {
 			if cpu.FlagZ() {
-				return cpu.jmp(uint16(args[0]))
+				return cpu.jmp(uint16(arg))
 			}
 			cpu.PC.IncBy(3)
 			return 12
 		},
 	},
-	/* TODO: 0xCB */
 	0xCC: {
-		mnemonic: "CALL Z, %#04x",
-		argLengths: []int{lword},
-		length: 3,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "CALL Z, %#04x",
+		argLength: lword,
+		length:    3,
+		handler: func(cpu *CPU, arg int) int {
 			if !cpu.FlagZ() {
 				cpu.PC.IncBy(3)
 				return 12
 			}
-			cpu.call(uint16(args[0]))
+			cpu.PC.IncBy(3)
+			cpu.call(uint16(arg))
 			return 24
 		},
 	},
 	0xCD: {
-		mnemonic:   "CALL %#04x",
-		argLengths: []int{lword},
-		length:     3,
-		handler: func(cpu *CPU, args ...int) int {
-			cpu.call(uint16(args[0]))
+		mnemonic:  "CALL %#04x",
+		argLength: lword,
+		length:    3,
+		handler: func(cpu *CPU, arg int) int {
+			cpu.PC.IncBy(3)
+			cpu.call(uint16(arg))
 			return 24
 		},
 	},
 	0xCE: {
-		mnemonic:   "ADC %#02x",
-		argLengths: []int{lbyte},
-		length:     2,
-		handler: func(cpu *CPU, args ...int) int {
-			cycles := cpu.adcR8d8(cpu.A, byte(args[0]))
+		mnemonic:  "ADC %#02x",
+		argLength: lbyte,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.adcR8d8(cpu.A, byte(arg))
 			cpu.PC.IncBy(2)
 			return cycles
 		},
 	},
 	0xCF: {
-		mnemonic:   "RST 08H",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "RST 08H",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			return cpu.rst(0x0008)
 		},
 	},
 	0xD0: {
-		mnemonic:   "RETNC",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
-			if (cpu.FlagC()) {
+		mnemonic:  "RETNC",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
+			if cpu.FlagC() {
 				cpu.PC.Inc()
 				return 8
 			}
@@ -2080,75 +2174,75 @@ var opDefinitions = map[uint8]opDefinition{
 		},
 	},
 	0xD1: {
-		mnemonic:   "POP DE",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "POP DE",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.popR16(cpu.DE)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xD2: {
-		mnemonic:   "JP NC, %#04x",
-		argLengths: []int{lword},
-		length:     3,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "JP NC, %#04x",
+		argLength: lword,
+		length:    3,
+		handler: func(cpu *CPU, arg int) int {
 			if !cpu.FlagC() {
-				return cpu.jmp(uint16(args[0]))
+				return cpu.jmp(uint16(arg))
 			}
 			cpu.PC.IncBy(3)
 			return 12
 		},
 	},
-	/* TODO: 0xD3 */
 	0xD4: {
-		mnemonic: "CALL NC, %#04x",
-		argLengths: []int{lword},
-		length: 3,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "CALL NC, %#04x",
+		argLength: lword,
+		length:    3,
+		handler: func(cpu *CPU, arg int) int {
 			if cpu.FlagC() {
 				cpu.PC.IncBy(3)
 				return 12
 			}
-			cpu.call(uint16(args[0]))
+			cpu.PC.IncBy(3)
+			cpu.call(uint16(arg))
 			return 24
 		},
 	},
 	0xD5: {
-		mnemonic:   "PUSH DE",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "PUSH DE",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.pushR16(cpu.DE)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xD6: {
-		mnemonic:   "SUB %#02x",
-		argLengths: []int{lbyte},
-		length:     2,
-		handler: func(cpu *CPU, args ...int) int {
-			cycles := cpu.subd8(byte(args[0]))
+		mnemonic:  "SUB %#02x",
+		argLength: lbyte,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.subd8(byte(arg))
 			cpu.PC.IncBy(2)
 			return cycles
 		},
 	},
 	0xD7: {
-		mnemonic:   "RST 10H",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "RST 10H",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			return cpu.rst(0x0010)
 		},
 	},
 	0xD8: {
-		mnemonic:   "RETC",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
-			if (!cpu.FlagC()) {
+		mnemonic:  "RETC",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
+			if !cpu.FlagC() {
 				cpu.PC.Inc()
 				return 8
 			}
@@ -2158,10 +2252,10 @@ var opDefinitions = map[uint8]opDefinition{
 		},
 	},
 	0xD9: {
-		mnemonic:   "RETI",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "RETI",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			addr := cpu.pop()
 			cpu.jump(addr)
 			cpu.EnableInterrupts()
@@ -2169,329 +2263,329 @@ var opDefinitions = map[uint8]opDefinition{
 		},
 	},
 	0xDA: {
-		mnemonic:   "JP C, %#04x",
-		argLengths: []int{lword},
-		length:     3,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "JP C, %#04x",
+		argLength: lword,
+		length:    3,
+		handler: func(cpu *CPU, arg int) int {
 			if cpu.FlagC() {
-				return cpu.jmp(uint16(args[0]))
+				return cpu.jmp(uint16(arg))
 			}
 			cpu.PC.IncBy(3)
 			return 12
 		},
 	},
-	/* TODO: 0xDB */
 	0xDC: {
-		mnemonic: "CALL C, %#04x",
-		argLengths: []int{lword},
-		length: 3,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "CALL C, %#04x",
+		argLength: lword,
+		length:    3,
+		handler: func(cpu *CPU, arg int) int {
 			if !cpu.FlagC() {
 				cpu.PC.IncBy(3)
 				return 12
 			}
-			cpu.call(uint16(args[0]))
+			cpu.PC.IncBy(3)
+			cpu.call(uint16(arg))
 			return 24
 		},
 	},
-	/* TODO: 0xDD */
 	0xDE: {
-		mnemonic:   "SBC %#02x",
-		argLengths: []int{lbyte},
-		length:     2,
-		handler: func(cpu *CPU, args ...int) int {
-			cycles := cpu.sbcD8(byte(args[0]))
+		mnemonic:  "SBC %#02x",
+		argLength: lbyte,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.sbcD8(byte(arg))
 			cpu.PC.IncBy(2)
 			return cycles
 		},
 	},
 	0xDF: {
-		mnemonic:   "RST 18H",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "RST 18H",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			return cpu.rst(0x0018)
 		},
 	},
 	0xE0: {
-		mnemonic:   "LDH (%#02x), A",
-		argLengths: []int{lbyte},
-		length:     2,
-		handler: func(cpu *CPU, args ...int) int {
-			cycles := cpu.ldha8R8(byte(args[0]), cpu.A)
+		mnemonic:  "LDH (%#02x), A",
+		argLength: lbyte,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.ldha8R8(byte(arg), cpu.A)
 			cpu.PC.IncBy(2)
 			return cycles
 		},
 	},
 	0xE1: {
-		mnemonic:   "POP HL",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "POP HL",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.popR16(cpu.HL)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xE2: {
-		mnemonic:   "LD (C), A",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD (C), A",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldhR8R8(cpu.C, cpu.A)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
-	/* TODO: 0xE3 */
-	/* TODO: 0xE4 */
 	0xE5: {
-		mnemonic:   "PUSH HL",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "PUSH HL",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.pushR16(cpu.HL)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xE6: {
-		mnemonic:   "AND %#02x",
-		argLengths: []int{lbyte},
-		length:     2,
-		handler: func(cpu *CPU, args ...int) int {
-			cycles := cpu.andD8(byte(args[0]))
+		mnemonic:  "AND %#02x",
+		argLength: lbyte,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.andD8(byte(arg))
 			cpu.PC.IncBy(2)
 			return cycles
 		},
 	},
 	0xE7: {
-		mnemonic:   "RST 20H",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "RST 20H",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			return cpu.rst(0x0020)
 		},
 	},
 	0xE8: {
-		mnemonic:   "ADD SP, %#02x",
-		argLengths: []int{lbyte},
-		length:     2,
-		handler: func(cpu *CPU, args ...int) int {
-			cycles := cpu.addSP(int8(args[0]))
+		mnemonic:  "ADD SP, %#02x",
+		argLength: lbyte,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.addSP(int8(arg))
 			cpu.PC.IncBy(2)
 			return cycles
 		},
 	},
 	0xE9: {
-		mnemonic:   "JP (HL)",
-		argLengths: []int{lword},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "JP (HL)",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			return cpu.jmpR16(cpu.HL)
 		},
 	},
 	0xEA: {
-		mnemonic:   "LD (%#04x), A",
-		argLengths: []int{lword},
-		length:     3,
-		handler: func(cpu *CPU, args ...int) int {
-			cycles := cpu.lda16R8(uint16(args[0]), cpu.A)
+		mnemonic:  "LD (%#04x), A",
+		argLength: lword,
+		length:    3,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.lda16R8(uint16(arg), cpu.A)
 			cpu.PC.IncBy(3)
 			return cycles
 		},
 	},
-	/* TODO: 0xEB */
-	/* TODO: 0xEC */
-	/* TODO: 0xED */
 	0xEE: {
-		mnemonic:   "XOR %#02x",
-		argLengths: []int{lbyte},
-		length:     2,
-		handler: func(cpu *CPU, args ...int) int {
-			cycles := cpu.xorD8(byte(args[0]))
+		mnemonic:  "XOR %#02x",
+		argLength: lbyte,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.xorD8(byte(arg))
 			cpu.PC.IncBy(2)
 			return cycles
 		},
 	},
 	0xEF: {
-		mnemonic:   "RST 28H",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "RST 28H",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			return cpu.rst(0x0028)
 		},
 	},
 	0xF0: {
-		mnemonic:   "LDH A, (%#02x)",
-		argLengths: []int{lbyte},
-		length:     2,
-		handler: func(cpu *CPU, args ...int) int {
-			cycles := cpu.ldhR8a8(cpu.A, byte(args[0]))
+		mnemonic:  "LDH A, (%#02x)",
+		argLength: lbyte,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.ldhR8a8(cpu.A, byte(arg))
 			cpu.PC.IncBy(2)
 			return cycles
 		},
 	},
 	0xF1: {
-		mnemonic:   "POP AF",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "POP AF",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.popR16(cpu.AF)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xF2: {
-		mnemonic:   "LD A, (C)",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD A, (C)",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR8hR8(cpu.A, cpu.C)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xF3: {
-		mnemonic:   "DI",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "DI",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cpu.DisableInterrupts()
 			cpu.PC.Inc()
 			return 4
 		},
 	},
-	/* TODO: 0xF4 */
 	0xF5: {
-		mnemonic:   "PUSH AF",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "PUSH AF",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.pushR16(cpu.AF)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xF6: {
-		mnemonic:   "OR %#02",
-		argLengths: []int{lbyte},
-		length:     2,
-		handler: func(cpu *CPU, args ...int) int {
-			cycles := cpu.orD8(byte(args[0]))
+		mnemonic:  "OR %#02",
+		argLength: lbyte,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.orD8(byte(arg))
 			cpu.PC.IncBy(2)
 			return cycles
 		},
 	},
 	0xF7: {
-		mnemonic:   "RST 30H",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "RST 30H",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			return cpu.rst(0x0030)
 		},
 	},
 	0xF8: {
-		mnemonic:   "LDHL SP, %#02x",
-		argLengths: []int{lbyte},
-		length:     2,
-		handler: func(cpu *CPU, args ...int) int {
-			cycles := cpu.ldR16R16a8(cpu.HL, cpu.SP, int8(args[0]))
+		mnemonic:  "LDHL SP, %#02x",
+		argLength: lbyte,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.ldR16R16a8(cpu.HL, cpu.SP, int8(arg))
 			cpu.PC.IncBy(2)
 			return cycles
 		},
 	},
 	0xF9: {
-		mnemonic:   "LD SP, HL",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "LD SP, HL",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			cycles := cpu.ldR16R16(cpu.SP, cpu.HL)
 			cpu.PC.Inc()
 			return cycles
 		},
 	},
 	0xFA: {
-		mnemonic:   "LD A, (%#04x)",
-		argLengths: []int{lword},
-		length:     3,
-		handler: func(cpu *CPU, args ...int) int {
-			cycles := cpu.ldR8a16(cpu.A, uint16(args[0]))
+		mnemonic:  "LD A, (%#04x)",
+		argLength: lword,
+		length:    3,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.ldR8a16(cpu.A, uint16(arg))
 			cpu.PC.IncBy(3)
 			return cycles
 		},
 	},
 	0xFB: {
-		mnemonic:   "EI",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
-			cpu.EnableInterrupts()
+		mnemonic:  "EI",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
+			cpu.ScheduleEnableInterrupts()
 			cpu.PC.Inc()
 			return 4
 		},
 	},
-	/* TODO: 0xFC */
-	/* TODO: 0xFD */
 	0xFE: {
-		mnemonic:   "CP %#02x",
-		argLengths: []int{lbyte},
-		length:     2,
-		handler: func(cpu *CPU, args ...int) int {
-			cycles := cpu.cpD8(byte(args[0]))
+		mnemonic:  "CP %#02x",
+		argLength: lbyte,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.cpD8(byte(arg))
 			cpu.PC.IncBy(2)
 			return cycles
 		},
 	},
 	0xFF: {
-		mnemonic:   "RST 38H",
-		argLengths: []int{},
-		length:     1,
-		handler: func(cpu *CPU, args ...int) int {
+		mnemonic:  "RST 38H",
+		argLength: lnone,
+		length:    1,
+		handler: func(cpu *CPU, arg int) int {
 			return cpu.rst(0x0038)
 		},
 	},
 }
 
-func opCodeFrom(data uint32) (op, error) {
-	opCode := byte((data & 0xFF000000) >> 24)
-	opDefinition, f := opDefinitions[opCode]
-	if f != true {
-		return op{}, fmt.Errorf("Unknown opcode %#02x", opCode)
+func (cpu *CPU) opCodeAt(address uint16) (op, error) {
+	return cpu.decode(address, address+1)
+}
+
+func (cpu *CPU) opCodeWithHaltBugAt(address uint16) (op, error) {
+	return cpu.decode(address, address)
+}
+
+// decode reads the opcode at address and only the operand bytes it uses,
+// starting at operands
+func (cpu *CPU) decode(address, operands uint16) (op, error) {
+	opCode := cpu.memoryReadByte(address)
+	definition := &opDefinitions[opCode]
+	if opCode == cbPrefix {
+		opCode = cpu.memoryReadByte(operands)
+		definition = &cbOpDefinitions[opCode]
+		operands++
 	}
 
-	args := []int{}
-	data = data << 8
-	for _, s := range opDefinition.argLengths {
-		switch s {
-		case lbyte:
-			arg := (data & 0xFF000000) >> 24
-			args = append(args, int(arg))
-			data = data << 8
-		case lword:
-			arg := bits.FlipWord(uint16((data & 0xFFFF0000) >> 16))
-			args = append(args, int(arg))
-			data = data << 16
-		default:
-			panic("Unknown argument type")
-		}
+	if definition.handler == nil {
+		return op{}, cpu.illegalOpcode(address, opCode)
 	}
 
-	op := op{
-		mnemonic: opDefinition.mnemonic,
-		args:     args,
-		length:   opDefinition.length,
-		handler:  opDefinition.handler,
+	var arg int
+	switch definition.argLength {
+	case lbyte:
+		arg = int(cpu.memoryReadByte(operands))
+	case lword:
+		arg = int(cpu.memoryReadWord(operands))
 	}
-	return op, nil
+	return op{definition, arg}, nil
 }
 
-func (cpu *CPU) opCodeAt(address uint16) (op, error) {
-	data := cpu.memoryReadDWord(address)
-	return opCodeFrom(data)
+func (cpu *CPU) nop() int {
+	return 4
 }
 
-func (cpu *CPU) nop() int {
+func (cpu *CPU) halt() int {
+	if !cpu.imeFlag && cpu.interruptPending() {
+		cpu.haltBug = true
+		return 4
+	}
+	cpu.halted = true
+	return 4
+}
+
+func (cpu *CPU) stop() int {
+	cpu.stopped = true
 	return 4
 }
 
@@ -2503,7 +2597,7 @@ func (cpu *CPU) jmp(a16 uint16) int {
 func (cpu *CPU) jr(r8 int8) int {
 	var a16 uint16
 	if r8 < 0 {
-		a16 = cpu.PC.Get() - uint16(^r8 - 1)
+		a16 = cpu.PC.Get() - uint16(^r8-1)
 	} else {
 		a16 = cpu.PC.Get() + uint16(r8)
 	}
@@ -2568,27 +2662,9 @@ func (cpu *CPU) popR16(r *WordRegister) int {
 }
 
 func (cpu *CPU) ldR16R16a8(r1, r2 *WordRegister, r8 int8) int {
-	var carry, halfCarry bool
-	var a16 uint16
-	if r8 < 0 {
-		rel := uint16(^r8 - 1)
-		a16 = uint16(r2.Get() - rel)
-		halfCarry = bits.HalfCarrySubWord(r2.Get(), rel)
-		carry = bits.CarrySubWord(r2.Get(), rel)
-	} else {
-		rel := uint16(r8)
-		a16 = uint16(r2.Get() + rel)
-		halfCarry = bits.HalfCarryAddWord(r2.Get(), rel)
-		carry = bits.CarryAddWord(r2.Get(), rel)
-	}
-	l := cpu.memoryReadByte(a16)
-	h := cpu.memoryReadByte(a16 + 1)
-	d16 := bits.ConcatWord(h, l)
-	r1.Set(d16)
-	cpu.SetFlagZ(false)
-	cpu.SetFlagN(false)
-	cpu.SetFlagH(halfCarry)
-	cpu.SetFlagC(carry)
+	result, flags := cpu.addSigned(r2.Get(), r8)
+	r1.Set(result)
+	cpu.F.Set(flags)
 	return 12
 }
 
@@ -2826,15 +2902,9 @@ func (cpu *CPU) adcR8d8(r1 *ByteRegister, d8 byte) int {
 }
 
 func (cpu *CPU) addSP(r8 int8) int {
-	var result uint16
-	var flags byte
-	if r8 < 0 {
-		result, flags = cpu.subWord(cpu.SP.Get(), uint16(^r8 - 1), false)
-	} else {
-		result, flags = cpu.addWord(cpu.SP.Get(), uint16(r8), false)
-	}
+	result, flags := cpu.addSigned(cpu.SP.Get(), r8)
 	cpu.SP.Set(result)
-	cpu.F.Set(flags & ^flagZ & ^flagN)
+	cpu.F.Set(flags)
 	return 16
 }
 
@@ -2875,6 +2945,7 @@ func (cpu *CPU) decR16(r *WordRegister) int {
 }
 
 func (cpu *CPU) rst(addr uint16) int {
+	cpu.PC.Inc()
 	cpu.push(cpu.PC.Get())
 	cpu.jump(addr)
 	return 16
@@ -2882,17 +2953,17 @@ func (cpu *CPU) rst(addr uint16) int {
 
 func buildFlags(Z, N, H, C bool) byte {
 	var flags byte
-	if (Z) {
+	if Z {
 		flags |= flagZ
 	}
-	if (N) {
+	if N {
 		flags |= flagN
 	}
-	if (H) {
+	if H {
 		flags |= flagH
 	}
-	if (C) {
+	if C {
 		flags |= flagC
 	}
 	return flags
-}
\ No newline at end of file
+}
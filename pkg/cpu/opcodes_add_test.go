@@ -336,9 +336,9 @@ func TestAddSignedRelativeToSPClearsNegativeFlag(t *testing.T) {
 func TestAddSignedRelativeToSPDecrementsSPWithNegativeNumbers(t *testing.T) {
 	testDescription := testDescription{
 		description:      fmt.Sprintf("'ADD SP, %#02x' decrements SP with signed negative numbers (0xFE = -2).", 0xFE),
-		opcode:           opcode{0xE8, 0xFC},
+		opcode:           opcode{0xE8, 0xFE},
 		regsGiven:        regMap{"SP": 0x0012},
-		regsExpected:     regMap{"SP": 0x0010},
+		regsExpected:     regMap{"SP": 0x0010, "F": FlagH | FlagC},
 		memReadExpected:  memMap{},
 		memWriteExpected: memMap{},
 		cycles:           16,
@@ -363,10 +363,10 @@ func TestAddSignedRelativeToSPClearsZeroFlagEvenWithResultZero(t *testing.T) {
 
 func TestAddSignedRelativeToSPSetsHalfCarryFlag(t *testing.T) {
 	testDescription := testDescription{
-		description:      fmt.Sprintf("'ADD SP, %#02x' sets half-carry flag when carry in bits 3-4.", 0x02),
-		opcode:           opcode{0xE8, 0x02},
-		regsGiven:        regMap{"SP": 0x0FFF},
-		regsExpected:     regMap{"SP": 0x1001, "F": FlagH},
+		description:      fmt.Sprintf("'ADD SP, %#02x' sets half-carry flag when carry in bits 3-4.", 0x01),
+		opcode:           opcode{0xE8, 0x01},
+		regsGiven:        regMap{"SP": 0x0F0F},
+		regsExpected:     regMap{"SP": 0x0F10, "F": FlagH},
 		memReadExpected:  memMap{},
 		memWriteExpected: memMap{},
 		cycles:           16,
@@ -389,6 +389,52 @@ func TestAddSignedRelativeToSPSetsCarryFlagWhenOverflown(t *testing.T) {
 	testCase.Run(t)
 }
 
+func TestAddSignedRelativeToSPTakesFlagsFromLowByte(t *testing.T) {
+	tests := []testDescription{
+		{
+			description:      "'ADD SP, r8' sets carry flag from the low byte only",
+			opcode:           opcode{0xE8, 0x01},
+			regsGiven:        regMap{"SP": 0x12FF},
+			regsExpected:     regMap{"SP": 0x1300, "F": FlagH | FlagC},
+			memReadExpected:  memMap{},
+			memWriteExpected: memMap{},
+			cycles:           16,
+		},
+		{
+			description:      "'ADD SP, r8' sets H and C adding negative r8 as an unsigned byte",
+			opcode:           opcode{0xE8, 0xFF},
+			regsGiven:        regMap{"SP": 0x1234},
+			regsExpected:     regMap{"SP": 0x1233, "F": FlagH | FlagC},
+			memReadExpected:  memMap{},
+			memWriteExpected: memMap{},
+			cycles:           16,
+		},
+		{
+			description:      "'ADD SP, r8' clears H and C when negative r8 does not carry out of the low byte",
+			opcode:           opcode{0xE8, 0xFF},
+			regsGiven:        regMap{"SP": 0x1200, "F": FlagH | FlagC},
+			regsExpected:     regMap{"SP": 0x11FF, "F": 0},
+			memReadExpected:  memMap{},
+			memWriteExpected: memMap{},
+			cycles:           16,
+		},
+		{
+			description:      "'ADD SP, r8' wraps around with negative r8",
+			opcode:           opcode{0xE8, 0x80},
+			regsGiven:        regMap{"SP": 0x0010},
+			regsExpected:     regMap{"SP": 0xFF90, "F": 0},
+			memReadExpected:  memMap{},
+			memWriteExpected: memMap{},
+			cycles:           16,
+		},
+	}
+
+	for _, test := range tests {
+		testCase := buildTestCase(test)
+		testCase.Run(t)
+	}
+}
+
 func TestAddImmediateClearsNegativeFlag(t *testing.T) {
 	testDescription := testDescription{
 		description:      fmt.Sprintf("'ADD A, %#02x' adds %#02x value to A and stores result in A. Negative flag cleared.", 0x34, 0x34),
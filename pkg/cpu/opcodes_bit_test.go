@@ -0,0 +1,114 @@
+package cpu_test
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestBitTestsRegisterBit(t *testing.T) {
+	for bit := uint8(0); bit < 8; bit++ {
+		for r8, offset := range cbRegisterOffsets {
+			opc := opcode{0xCB, 0x40 + bit<<3 + offset}
+			tests := []testDescription{
+				{
+					fmt.Sprintf("'BIT %d, %s' clears zero flag when bit is set, keeping carry flag", bit, r8),
+					opc,
+					regMap{r8: 1 << bit, "F": FlagZ | FlagN | FlagC},
+					regMap{"F": FlagH | FlagC},
+					memMap{},
+					memMap{},
+					8,
+				},
+				{
+					fmt.Sprintf("'BIT %d, %s' sets zero flag when bit is clear", bit, r8),
+					opc,
+					regMap{r8: int(^byte(1 << bit)), "F": FlagN},
+					regMap{"F": FlagZ | FlagH},
+					memMap{},
+					memMap{},
+					8,
+				},
+			}
+			for _, test := range tests {
+				testCase := buildTestCase(test)
+				testCase.Run(t)
+			}
+		}
+	}
+}
+
+func TestBitTestsIndirectBit(t *testing.T) {
+	for bit := uint8(0); bit < 8; bit++ {
+		testDescription := testDescription{
+			fmt.Sprintf("'BIT %d, (HL)' sets zero flag when bit is clear", bit),
+			opcode{0xCB, 0x46 + bit<<3},
+			regMap{"HL": 0x1234, "F": FlagC},
+			regMap{"F": FlagZ | FlagH | FlagC},
+			memMap{0x1234: ^byte(1 << bit)},
+			memMap{},
+			12,
+		}
+		testCase := buildTestCase(testDescription)
+		testCase.Run(t)
+	}
+}
+
+func TestResAndSetModifyRegisterBit(t *testing.T) {
+	for bit := uint8(0); bit < 8; bit++ {
+		for r8, offset := range cbRegisterOffsets {
+			tests := []testDescription{
+				{
+					fmt.Sprintf("'RES %d, %s' clears bit %d without affecting flags", bit, r8, bit),
+					opcode{0xCB, 0x80 + bit<<3 + offset},
+					regMap{r8: 0xFF, "F": FlagZ | FlagC},
+					regMap{r8: int(^byte(1 << bit))},
+					memMap{},
+					memMap{},
+					8,
+				},
+				{
+					fmt.Sprintf("'SET %d, %s' sets bit %d without affecting flags", bit, r8, bit),
+					opcode{0xCB, 0xC0 + bit<<3 + offset},
+					regMap{r8: 0x00, "F": FlagN | FlagH},
+					regMap{r8: 1 << bit},
+					memMap{},
+					memMap{},
+					8,
+				},
+			}
+			for _, test := range tests {
+				testCase := buildTestCase(test)
+				testCase.Run(t)
+			}
+		}
+	}
+}
+
+func TestResAndSetModifyIndirectBit(t *testing.T) {
+	for bit := uint8(0); bit < 8; bit++ {
+		tests := []testDescription{
+			{
+				fmt.Sprintf("'RES %d, (HL)' clears bit %d of memory content (HL)", bit, bit),
+				opcode{0xCB, 0x86 + bit<<3},
+				regMap{"HL": 0x1234},
+				regMap{},
+				memMap{0x1234: 0xFF},
+				memMap{0x1234: ^byte(1 << bit)},
+				16,
+			},
+			{
+				fmt.Sprintf("'SET %d, (HL)' sets bit %d of memory content (HL)", bit, bit),
+				opcode{0xCB, 0xC6 + bit<<3},
+				regMap{"HL": 0x1234},
+				regMap{},
+				memMap{0x1234: 0x00},
+				memMap{0x1234: 1 << bit},
+				16,
+			},
+		}
+		for _, test := range tests {
+			testCase := buildTestCase(test)
+			testCase.Run(t)
+		}
+	}
+}
@@ -2,6 +2,8 @@ package cpu_test
 
 import (
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestCallNZJumpsAndSetsSPWhenZeroFlagClear(t *testing.T) {
@@ -12,7 +14,7 @@ func TestCallNZJumpsAndSetsSPWhenZeroFlagClear(t *testing.T) {
 			regsGiven:        regMap{"PC": 0x100, "SP": 0x402},
 			regsExpected:     regMap{"PC": 0x1234, "SP": 0x400},
 			memReadExpected:  memMap{},
-			memWriteExpected: memMap{0x401: 0x01, 0x400: 0x00},
+			memWriteExpected: memMap{0x401: 0x01, 0x400: 0x03},
 			cycles:           24,
 		},
 		{
@@ -41,7 +43,7 @@ func TestCallNCJumpsAndSetsSPWhenCarryFlagClear(t *testing.T) {
 			regsGiven:        regMap{"PC": 0x100, "SP": 0x402},
 			regsExpected:     regMap{"PC": 0x1234, "SP": 0x400},
 			memReadExpected:  memMap{},
-			memWriteExpected: memMap{0x401: 0x01, 0x400: 0x00},
+			memWriteExpected: memMap{0x401: 0x01, 0x400: 0x03},
 			cycles:           24,
 		},
 		{
@@ -69,7 +71,7 @@ func TestCallZJumpsAndSetsSPWhenZeroFlagSet(t *testing.T) {
 			regsGiven:        regMap{"PC": 0x100, "SP": 0x402, "F": FlagZ},
 			regsExpected:     regMap{"PC": 0x1234, "SP": 0x400},
 			memReadExpected:  memMap{},
-			memWriteExpected: memMap{0x401: 0x01, 0x400: 0x00},
+			memWriteExpected: memMap{0x401: 0x01, 0x400: 0x03},
 			cycles:           24,
 		},
 		{
@@ -97,7 +99,7 @@ func TestCallCJumpsAndSetsSPWhenCarryFlagSet(t *testing.T) {
 			regsGiven:        regMap{"PC": 0x100, "SP": 0x402, "F": FlagC},
 			regsExpected:     regMap{"PC": 0x1234, "SP": 0x400},
 			memReadExpected:  memMap{},
-			memWriteExpected: memMap{0x401: 0x01, 0x400: 0x00},
+			memWriteExpected: memMap{0x401: 0x01, 0x400: 0x03},
 			cycles:           24,
 		},
 		{
@@ -125,7 +127,7 @@ func TestCallJumpsAndSetsSP(t *testing.T) {
 			regsGiven:        regMap{"PC": 0x100, "SP": 0x402},
 			regsExpected:     regMap{"PC": 0x1234, "SP": 0x400},
 			memReadExpected:  memMap{},
-			memWriteExpected: memMap{0x401: 0x01, 0x400: 0x00},
+			memWriteExpected: memMap{0x401: 0x01, 0x400: 0x03},
 			cycles:           24,
 		},
 	}
@@ -134,4 +136,24 @@ func TestCallJumpsAndSetsSP(t *testing.T) {
 		testCase := buildTestCase(test)
 		testCase.Run(t)
 	}
-}
\ No newline at end of file
+}
+
+func TestCallReturnsToTheNextInstruction(t *testing.T) {
+	ram := map[uint16]byte{
+		0x100: 0xCD, // CALL 0x0200
+		0x101: 0x00,
+		0x102: 0x02,
+		0x103: 0x04, // INC B
+		0x200: 0xC9, // RET
+	}
+	c, ctrl := newRAMBackedCPU(t, ram)
+	defer ctrl.Finish()
+
+	for i := 0; i < 3; i++ {
+		_, err := c.Step()
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, uint16(0x104), c.Status().PC)
+	assert.Equal(t, uint16(0x0100), c.Status().BC)
+	assert.Equal(t, uint16(0xFFFE), c.Status().SP)
+}
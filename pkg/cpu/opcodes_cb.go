@@ -0,0 +1,2710 @@
+package cpu
+
+const cbPrefix = 0xCB
+
+var cbOpDefinitions = [256]opDefinition{
+	0x00: {
+		mnemonic:  "RLC B",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.rlcR8(cpu.B)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x01: {
+		mnemonic:  "RLC C",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.rlcR8(cpu.C)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x02: {
+		mnemonic:  "RLC D",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.rlcR8(cpu.D)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x03: {
+		mnemonic:  "RLC E",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.rlcR8(cpu.E)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x04: {
+		mnemonic:  "RLC H",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.rlcR8(cpu.H)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x05: {
+		mnemonic:  "RLC L",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.rlcR8(cpu.L)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x06: {
+		mnemonic:  "RLC (HL)",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.rlcaR16(cpu.HL)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x07: {
+		mnemonic:  "RLC A",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.rlcR8(cpu.A)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x08: {
+		mnemonic:  "RRC B",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.rrcR8(cpu.B)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x09: {
+		mnemonic:  "RRC C",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.rrcR8(cpu.C)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x0A: {
+		mnemonic:  "RRC D",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.rrcR8(cpu.D)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x0B: {
+		mnemonic:  "RRC E",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.rrcR8(cpu.E)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x0C: {
+		mnemonic:  "RRC H",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.rrcR8(cpu.H)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x0D: {
+		mnemonic:  "RRC L",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.rrcR8(cpu.L)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x0E: {
+		mnemonic:  "RRC (HL)",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.rrcaR16(cpu.HL)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x0F: {
+		mnemonic:  "RRC A",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.rrcR8(cpu.A)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x10: {
+		mnemonic:  "RL B",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.rlR8(cpu.B)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x11: {
+		mnemonic:  "RL C",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.rlR8(cpu.C)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x12: {
+		mnemonic:  "RL D",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.rlR8(cpu.D)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x13: {
+		mnemonic:  "RL E",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.rlR8(cpu.E)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x14: {
+		mnemonic:  "RL H",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.rlR8(cpu.H)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x15: {
+		mnemonic:  "RL L",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.rlR8(cpu.L)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x16: {
+		mnemonic:  "RL (HL)",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.rlaR16(cpu.HL)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x17: {
+		mnemonic:  "RL A",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.rlR8(cpu.A)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x18: {
+		mnemonic:  "RR B",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.rrR8(cpu.B)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x19: {
+		mnemonic:  "RR C",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.rrR8(cpu.C)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x1A: {
+		mnemonic:  "RR D",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.rrR8(cpu.D)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x1B: {
+		mnemonic:  "RR E",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.rrR8(cpu.E)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x1C: {
+		mnemonic:  "RR H",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.rrR8(cpu.H)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x1D: {
+		mnemonic:  "RR L",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.rrR8(cpu.L)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x1E: {
+		mnemonic:  "RR (HL)",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.rraR16(cpu.HL)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x1F: {
+		mnemonic:  "RR A",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.rrR8(cpu.A)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x20: {
+		mnemonic:  "SLA B",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.slaR8(cpu.B)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x21: {
+		mnemonic:  "SLA C",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.slaR8(cpu.C)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x22: {
+		mnemonic:  "SLA D",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.slaR8(cpu.D)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x23: {
+		mnemonic:  "SLA E",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.slaR8(cpu.E)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x24: {
+		mnemonic:  "SLA H",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.slaR8(cpu.H)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x25: {
+		mnemonic:  "SLA L",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.slaR8(cpu.L)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x26: {
+		mnemonic:  "SLA (HL)",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.slaaR16(cpu.HL)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x27: {
+		mnemonic:  "SLA A",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.slaR8(cpu.A)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x28: {
+		mnemonic:  "SRA B",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.sraR8(cpu.B)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x29: {
+		mnemonic:  "SRA C",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.sraR8(cpu.C)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x2A: {
+		mnemonic:  "SRA D",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.sraR8(cpu.D)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x2B: {
+		mnemonic:  "SRA E",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.sraR8(cpu.E)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x2C: {
+		mnemonic:  "SRA H",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.sraR8(cpu.H)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x2D: {
+		mnemonic:  "SRA L",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.sraR8(cpu.L)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x2E: {
+		mnemonic:  "SRA (HL)",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.sraaR16(cpu.HL)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x2F: {
+		mnemonic:  "SRA A",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.sraR8(cpu.A)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x30: {
+		mnemonic:  "SWAP B",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.swapR8(cpu.B)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x31: {
+		mnemonic:  "SWAP C",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.swapR8(cpu.C)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x32: {
+		mnemonic:  "SWAP D",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.swapR8(cpu.D)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x33: {
+		mnemonic:  "SWAP E",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.swapR8(cpu.E)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x34: {
+		mnemonic:  "SWAP H",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.swapR8(cpu.H)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x35: {
+		mnemonic:  "SWAP L",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.swapR8(cpu.L)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x36: {
+		mnemonic:  "SWAP (HL)",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.swapaR16(cpu.HL)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x37: {
+		mnemonic:  "SWAP A",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.swapR8(cpu.A)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x38: {
+		mnemonic:  "SRL B",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.srlR8(cpu.B)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x39: {
+		mnemonic:  "SRL C",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.srlR8(cpu.C)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x3A: {
+		mnemonic:  "SRL D",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.srlR8(cpu.D)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x3B: {
+		mnemonic:  "SRL E",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.srlR8(cpu.E)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x3C: {
+		mnemonic:  "SRL H",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.srlR8(cpu.H)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x3D: {
+		mnemonic:  "SRL L",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.srlR8(cpu.L)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x3E: {
+		mnemonic:  "SRL (HL)",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.srlaR16(cpu.HL)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x3F: {
+		mnemonic:  "SRL A",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.srlR8(cpu.A)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x40: {
+		mnemonic:  "BIT 0, B",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(0, cpu.B)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x41: {
+		mnemonic:  "BIT 0, C",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(0, cpu.C)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x42: {
+		mnemonic:  "BIT 0, D",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(0, cpu.D)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x43: {
+		mnemonic:  "BIT 0, E",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(0, cpu.E)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x44: {
+		mnemonic:  "BIT 0, H",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(0, cpu.H)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x45: {
+		mnemonic:  "BIT 0, L",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(0, cpu.L)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x46: {
+		mnemonic:  "BIT 0, (HL)",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitaR16(0, cpu.HL)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x47: {
+		mnemonic:  "BIT 0, A",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(0, cpu.A)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x48: {
+		mnemonic:  "BIT 1, B",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(1, cpu.B)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x49: {
+		mnemonic:  "BIT 1, C",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(1, cpu.C)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x4A: {
+		mnemonic:  "BIT 1, D",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(1, cpu.D)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x4B: {
+		mnemonic:  "BIT 1, E",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(1, cpu.E)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x4C: {
+		mnemonic:  "BIT 1, H",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(1, cpu.H)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x4D: {
+		mnemonic:  "BIT 1, L",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(1, cpu.L)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x4E: {
+		mnemonic:  "BIT 1, (HL)",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitaR16(1, cpu.HL)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x4F: {
+		mnemonic:  "BIT 1, A",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(1, cpu.A)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x50: {
+		mnemonic:  "BIT 2, B",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(2, cpu.B)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x51: {
+		mnemonic:  "BIT 2, C",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(2, cpu.C)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x52: {
+		mnemonic:  "BIT 2, D",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(2, cpu.D)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x53: {
+		mnemonic:  "BIT 2, E",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(2, cpu.E)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x54: {
+		mnemonic:  "BIT 2, H",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(2, cpu.H)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x55: {
+		mnemonic:  "BIT 2, L",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(2, cpu.L)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x56: {
+		mnemonic:  "BIT 2, (HL)",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitaR16(2, cpu.HL)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x57: {
+		mnemonic:  "BIT 2, A",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(2, cpu.A)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x58: {
+		mnemonic:  "BIT 3, B",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(3, cpu.B)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x59: {
+		mnemonic:  "BIT 3, C",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(3, cpu.C)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x5A: {
+		mnemonic:  "BIT 3, D",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(3, cpu.D)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x5B: {
+		mnemonic:  "BIT 3, E",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(3, cpu.E)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x5C: {
+		mnemonic:  "BIT 3, H",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(3, cpu.H)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x5D: {
+		mnemonic:  "BIT 3, L",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(3, cpu.L)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x5E: {
+		mnemonic:  "BIT 3, (HL)",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitaR16(3, cpu.HL)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x5F: {
+		mnemonic:  "BIT 3, A",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(3, cpu.A)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x60: {
+		mnemonic:  "BIT 4, B",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(4, cpu.B)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x61: {
+		mnemonic:  "BIT 4, C",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(4, cpu.C)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x62: {
+		mnemonic:  "BIT 4, D",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(4, cpu.D)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x63: {
+		mnemonic:  "BIT 4, E",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(4, cpu.E)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x64: {
+		mnemonic:  "BIT 4, H",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(4, cpu.H)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x65: {
+		mnemonic:  "BIT 4, L",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(4, cpu.L)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x66: {
+		mnemonic:  "BIT 4, (HL)",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitaR16(4, cpu.HL)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x67: {
+		mnemonic:  "BIT 4, A",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(4, cpu.A)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x68: {
+		mnemonic:  "BIT 5, B",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(5, cpu.B)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x69: {
+		mnemonic:  "BIT 5, C",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(5, cpu.C)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x6A: {
+		mnemonic:  "BIT 5, D",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(5, cpu.D)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x6B: {
+		mnemonic:  "BIT 5, E",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(5, cpu.E)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x6C: {
+		mnemonic:  "BIT 5, H",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(5, cpu.H)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x6D: {
+		mnemonic:  "BIT 5, L",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(5, cpu.L)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x6E: {
+		mnemonic:  "BIT 5, (HL)",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitaR16(5, cpu.HL)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x6F: {
+		mnemonic:  "BIT 5, A",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(5, cpu.A)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x70: {
+		mnemonic:  "BIT 6, B",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(6, cpu.B)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x71: {
+		mnemonic:  "BIT 6, C",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(6, cpu.C)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x72: {
+		mnemonic:  "BIT 6, D",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(6, cpu.D)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x73: {
+		mnemonic:  "BIT 6, E",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(6, cpu.E)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x74: {
+		mnemonic:  "BIT 6, H",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(6, cpu.H)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x75: {
+		mnemonic:  "BIT 6, L",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(6, cpu.L)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x76: {
+		mnemonic:  "BIT 6, (HL)",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitaR16(6, cpu.HL)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x77: {
+		mnemonic:  "BIT 6, A",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(6, cpu.A)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x78: {
+		mnemonic:  "BIT 7, B",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(7, cpu.B)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x79: {
+		mnemonic:  "BIT 7, C",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(7, cpu.C)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x7A: {
+		mnemonic:  "BIT 7, D",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(7, cpu.D)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x7B: {
+		mnemonic:  "BIT 7, E",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(7, cpu.E)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x7C: {
+		mnemonic:  "BIT 7, H",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(7, cpu.H)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x7D: {
+		mnemonic:  "BIT 7, L",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(7, cpu.L)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x7E: {
+		mnemonic:  "BIT 7, (HL)",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitaR16(7, cpu.HL)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x7F: {
+		mnemonic:  "BIT 7, A",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.bitR8(7, cpu.A)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x80: {
+		mnemonic:  "RES 0, B",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(0, cpu.B)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x81: {
+		mnemonic:  "RES 0, C",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(0, cpu.C)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x82: {
+		mnemonic:  "RES 0, D",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(0, cpu.D)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x83: {
+		mnemonic:  "RES 0, E",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(0, cpu.E)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x84: {
+		mnemonic:  "RES 0, H",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(0, cpu.H)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x85: {
+		mnemonic:  "RES 0, L",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(0, cpu.L)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x86: {
+		mnemonic:  "RES 0, (HL)",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resaR16(0, cpu.HL)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x87: {
+		mnemonic:  "RES 0, A",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(0, cpu.A)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x88: {
+		mnemonic:  "RES 1, B",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(1, cpu.B)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x89: {
+		mnemonic:  "RES 1, C",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(1, cpu.C)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x8A: {
+		mnemonic:  "RES 1, D",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(1, cpu.D)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x8B: {
+		mnemonic:  "RES 1, E",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(1, cpu.E)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x8C: {
+		mnemonic:  "RES 1, H",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(1, cpu.H)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x8D: {
+		mnemonic:  "RES 1, L",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(1, cpu.L)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x8E: {
+		mnemonic:  "RES 1, (HL)",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resaR16(1, cpu.HL)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x8F: {
+		mnemonic:  "RES 1, A",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(1, cpu.A)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x90: {
+		mnemonic:  "RES 2, B",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(2, cpu.B)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x91: {
+		mnemonic:  "RES 2, C",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(2, cpu.C)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x92: {
+		mnemonic:  "RES 2, D",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(2, cpu.D)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x93: {
+		mnemonic:  "RES 2, E",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(2, cpu.E)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x94: {
+		mnemonic:  "RES 2, H",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(2, cpu.H)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x95: {
+		mnemonic:  "RES 2, L",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(2, cpu.L)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x96: {
+		mnemonic:  "RES 2, (HL)",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resaR16(2, cpu.HL)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x97: {
+		mnemonic:  "RES 2, A",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(2, cpu.A)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x98: {
+		mnemonic:  "RES 3, B",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(3, cpu.B)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x99: {
+		mnemonic:  "RES 3, C",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(3, cpu.C)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x9A: {
+		mnemonic:  "RES 3, D",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(3, cpu.D)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x9B: {
+		mnemonic:  "RES 3, E",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(3, cpu.E)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x9C: {
+		mnemonic:  "RES 3, H",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(3, cpu.H)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x9D: {
+		mnemonic:  "RES 3, L",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(3, cpu.L)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x9E: {
+		mnemonic:  "RES 3, (HL)",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resaR16(3, cpu.HL)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0x9F: {
+		mnemonic:  "RES 3, A",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(3, cpu.A)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xA0: {
+		mnemonic:  "RES 4, B",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(4, cpu.B)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xA1: {
+		mnemonic:  "RES 4, C",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(4, cpu.C)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xA2: {
+		mnemonic:  "RES 4, D",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(4, cpu.D)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xA3: {
+		mnemonic:  "RES 4, E",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(4, cpu.E)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xA4: {
+		mnemonic:  "RES 4, H",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(4, cpu.H)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xA5: {
+		mnemonic:  "RES 4, L",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(4, cpu.L)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xA6: {
+		mnemonic:  "RES 4, (HL)",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resaR16(4, cpu.HL)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xA7: {
+		mnemonic:  "RES 4, A",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(4, cpu.A)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xA8: {
+		mnemonic:  "RES 5, B",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(5, cpu.B)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xA9: {
+		mnemonic:  "RES 5, C",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(5, cpu.C)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xAA: {
+		mnemonic:  "RES 5, D",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(5, cpu.D)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xAB: {
+		mnemonic:  "RES 5, E",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(5, cpu.E)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xAC: {
+		mnemonic:  "RES 5, H",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(5, cpu.H)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xAD: {
+		mnemonic:  "RES 5, L",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(5, cpu.L)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xAE: {
+		mnemonic:  "RES 5, (HL)",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resaR16(5, cpu.HL)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xAF: {
+		mnemonic:  "RES 5, A",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(5, cpu.A)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xB0: {
+		mnemonic:  "RES 6, B",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(6, cpu.B)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xB1: {
+		mnemonic:  "RES 6, C",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(6, cpu.C)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xB2: {
+		mnemonic:  "RES 6, D",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(6, cpu.D)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xB3: {
+		mnemonic:  "RES 6, E",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(6, cpu.E)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xB4: {
+		mnemonic:  "RES 6, H",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(6, cpu.H)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xB5: {
+		mnemonic:  "RES 6, L",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(6, cpu.L)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xB6: {
+		mnemonic:  "RES 6, (HL)",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resaR16(6, cpu.HL)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xB7: {
+		mnemonic:  "RES 6, A",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(6, cpu.A)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xB8: {
+		mnemonic:  "RES 7, B",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(7, cpu.B)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xB9: {
+		mnemonic:  "RES 7, C",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(7, cpu.C)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xBA: {
+		mnemonic:  "RES 7, D",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(7, cpu.D)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xBB: {
+		mnemonic:  "RES 7, E",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(7, cpu.E)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xBC: {
+		mnemonic:  "RES 7, H",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(7, cpu.H)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xBD: {
+		mnemonic:  "RES 7, L",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(7, cpu.L)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xBE: {
+		mnemonic:  "RES 7, (HL)",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resaR16(7, cpu.HL)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xBF: {
+		mnemonic:  "RES 7, A",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.resR8(7, cpu.A)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xC0: {
+		mnemonic:  "SET 0, B",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(0, cpu.B)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xC1: {
+		mnemonic:  "SET 0, C",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(0, cpu.C)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xC2: {
+		mnemonic:  "SET 0, D",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(0, cpu.D)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xC3: {
+		mnemonic:  "SET 0, E",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(0, cpu.E)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xC4: {
+		mnemonic:  "SET 0, H",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(0, cpu.H)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xC5: {
+		mnemonic:  "SET 0, L",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(0, cpu.L)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xC6: {
+		mnemonic:  "SET 0, (HL)",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setaR16(0, cpu.HL)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xC7: {
+		mnemonic:  "SET 0, A",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(0, cpu.A)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xC8: {
+		mnemonic:  "SET 1, B",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(1, cpu.B)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xC9: {
+		mnemonic:  "SET 1, C",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(1, cpu.C)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xCA: {
+		mnemonic:  "SET 1, D",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(1, cpu.D)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xCB: {
+		mnemonic:  "SET 1, E",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(1, cpu.E)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xCC: {
+		mnemonic:  "SET 1, H",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(1, cpu.H)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xCD: {
+		mnemonic:  "SET 1, L",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(1, cpu.L)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xCE: {
+		mnemonic:  "SET 1, (HL)",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setaR16(1, cpu.HL)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xCF: {
+		mnemonic:  "SET 1, A",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(1, cpu.A)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xD0: {
+		mnemonic:  "SET 2, B",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(2, cpu.B)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xD1: {
+		mnemonic:  "SET 2, C",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(2, cpu.C)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xD2: {
+		mnemonic:  "SET 2, D",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(2, cpu.D)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xD3: {
+		mnemonic:  "SET 2, E",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(2, cpu.E)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xD4: {
+		mnemonic:  "SET 2, H",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(2, cpu.H)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xD5: {
+		mnemonic:  "SET 2, L",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(2, cpu.L)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xD6: {
+		mnemonic:  "SET 2, (HL)",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setaR16(2, cpu.HL)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xD7: {
+		mnemonic:  "SET 2, A",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(2, cpu.A)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xD8: {
+		mnemonic:  "SET 3, B",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(3, cpu.B)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xD9: {
+		mnemonic:  "SET 3, C",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(3, cpu.C)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xDA: {
+		mnemonic:  "SET 3, D",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(3, cpu.D)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xDB: {
+		mnemonic:  "SET 3, E",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(3, cpu.E)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xDC: {
+		mnemonic:  "SET 3, H",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(3, cpu.H)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xDD: {
+		mnemonic:  "SET 3, L",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(3, cpu.L)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xDE: {
+		mnemonic:  "SET 3, (HL)",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setaR16(3, cpu.HL)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xDF: {
+		mnemonic:  "SET 3, A",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(3, cpu.A)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xE0: {
+		mnemonic:  "SET 4, B",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(4, cpu.B)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xE1: {
+		mnemonic:  "SET 4, C",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(4, cpu.C)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xE2: {
+		mnemonic:  "SET 4, D",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(4, cpu.D)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xE3: {
+		mnemonic:  "SET 4, E",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(4, cpu.E)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xE4: {
+		mnemonic:  "SET 4, H",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(4, cpu.H)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xE5: {
+		mnemonic:  "SET 4, L",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(4, cpu.L)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xE6: {
+		mnemonic:  "SET 4, (HL)",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setaR16(4, cpu.HL)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xE7: {
+		mnemonic:  "SET 4, A",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(4, cpu.A)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xE8: {
+		mnemonic:  "SET 5, B",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(5, cpu.B)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xE9: {
+		mnemonic:  "SET 5, C",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(5, cpu.C)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xEA: {
+		mnemonic:  "SET 5, D",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(5, cpu.D)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xEB: {
+		mnemonic:  "SET 5, E",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(5, cpu.E)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xEC: {
+		mnemonic:  "SET 5, H",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(5, cpu.H)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xED: {
+		mnemonic:  "SET 5, L",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(5, cpu.L)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xEE: {
+		mnemonic:  "SET 5, (HL)",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setaR16(5, cpu.HL)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xEF: {
+		mnemonic:  "SET 5, A",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(5, cpu.A)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xF0: {
+		mnemonic:  "SET 6, B",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(6, cpu.B)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xF1: {
+		mnemonic:  "SET 6, C",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(6, cpu.C)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xF2: {
+		mnemonic:  "SET 6, D",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(6, cpu.D)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xF3: {
+		mnemonic:  "SET 6, E",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(6, cpu.E)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xF4: {
+		mnemonic:  "SET 6, H",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(6, cpu.H)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xF5: {
+		mnemonic:  "SET 6, L",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(6, cpu.L)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xF6: {
+		mnemonic:  "SET 6, (HL)",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setaR16(6, cpu.HL)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xF7: {
+		mnemonic:  "SET 6, A",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(6, cpu.A)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xF8: {
+		mnemonic:  "SET 7, B",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(7, cpu.B)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xF9: {
+		mnemonic:  "SET 7, C",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(7, cpu.C)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xFA: {
+		mnemonic:  "SET 7, D",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(7, cpu.D)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xFB: {
+		mnemonic:  "SET 7, E",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(7, cpu.E)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xFC: {
+		mnemonic:  "SET 7, H",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(7, cpu.H)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xFD: {
+		mnemonic:  "SET 7, L",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(7, cpu.L)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xFE: {
+		mnemonic:  "SET 7, (HL)",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setaR16(7, cpu.HL)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+	0xFF: {
+		mnemonic:  "SET 7, A",
+		argLength: lnone,
+		length:    2,
+		handler: func(cpu *CPU, arg int) int {
+			cycles := cpu.setR8(7, cpu.A)
+			cpu.PC.IncBy(2)
+			return cycles
+		},
+	},
+}
+
+func (cpu *CPU) rlcR8(r *ByteRegister) int {
+	result, flags := cpu.rlc(r.Get())
+	r.Set(result)
+	cpu.F.Set(flags)
+	return 8
+}
+
+func (cpu *CPU) rlcaR16(r *WordRegister) int {
+	result, flags := cpu.rlc(cpu.memoryReadByte(r.Get()))
+	cpu.memoryWriteByte(r.Get(), result)
+	cpu.F.Set(flags)
+	return 16
+}
+
+func (cpu *CPU) rrcR8(r *ByteRegister) int {
+	result, flags := cpu.rrc(r.Get())
+	r.Set(result)
+	cpu.F.Set(flags)
+	return 8
+}
+
+func (cpu *CPU) rrcaR16(r *WordRegister) int {
+	result, flags := cpu.rrc(cpu.memoryReadByte(r.Get()))
+	cpu.memoryWriteByte(r.Get(), result)
+	cpu.F.Set(flags)
+	return 16
+}
+
+func (cpu *CPU) rlR8(r *ByteRegister) int {
+	result, flags := cpu.rl(r.Get(), cpu.FlagC())
+	r.Set(result)
+	cpu.F.Set(flags)
+	return 8
+}
+
+func (cpu *CPU) rlaR16(r *WordRegister) int {
+	result, flags := cpu.rl(cpu.memoryReadByte(r.Get()), cpu.FlagC())
+	cpu.memoryWriteByte(r.Get(), result)
+	cpu.F.Set(flags)
+	return 16
+}
+
+func (cpu *CPU) rrR8(r *ByteRegister) int {
+	result, flags := cpu.rr(r.Get(), cpu.FlagC())
+	r.Set(result)
+	cpu.F.Set(flags)
+	return 8
+}
+
+func (cpu *CPU) rraR16(r *WordRegister) int {
+	result, flags := cpu.rr(cpu.memoryReadByte(r.Get()), cpu.FlagC())
+	cpu.memoryWriteByte(r.Get(), result)
+	cpu.F.Set(flags)
+	return 16
+}
+
+func (cpu *CPU) slaR8(r *ByteRegister) int {
+	result, flags := cpu.sla(r.Get())
+	r.Set(result)
+	cpu.F.Set(flags)
+	return 8
+}
+
+func (cpu *CPU) slaaR16(r *WordRegister) int {
+	result, flags := cpu.sla(cpu.memoryReadByte(r.Get()))
+	cpu.memoryWriteByte(r.Get(), result)
+	cpu.F.Set(flags)
+	return 16
+}
+
+func (cpu *CPU) sraR8(r *ByteRegister) int {
+	result, flags := cpu.sra(r.Get())
+	r.Set(result)
+	cpu.F.Set(flags)
+	return 8
+}
+
+func (cpu *CPU) sraaR16(r *WordRegister) int {
+	result, flags := cpu.sra(cpu.memoryReadByte(r.Get()))
+	cpu.memoryWriteByte(r.Get(), result)
+	cpu.F.Set(flags)
+	return 16
+}
+
+func (cpu *CPU) swapR8(r *ByteRegister) int {
+	result, flags := cpu.swap(r.Get())
+	r.Set(result)
+	cpu.F.Set(flags)
+	return 8
+}
+
+func (cpu *CPU) swapaR16(r *WordRegister) int {
+	result, flags := cpu.swap(cpu.memoryReadByte(r.Get()))
+	cpu.memoryWriteByte(r.Get(), result)
+	cpu.F.Set(flags)
+	return 16
+}
+
+func (cpu *CPU) srlR8(r *ByteRegister) int {
+	result, flags := cpu.srl(r.Get())
+	r.Set(result)
+	cpu.F.Set(flags)
+	return 8
+}
+
+func (cpu *CPU) srlaR16(r *WordRegister) int {
+	result, flags := cpu.srl(cpu.memoryReadByte(r.Get()))
+	cpu.memoryWriteByte(r.Get(), result)
+	cpu.F.Set(flags)
+	return 16
+}
+
+func (cpu *CPU) bitR8(bit uint8, r *ByteRegister) int {
+	cpu.F.Set(cpu.bit(r.Get(), bit, cpu.FlagC()))
+	return 8
+}
+
+func (cpu *CPU) bitaR16(bit uint8, r *WordRegister) int {
+	cpu.F.Set(cpu.bit(cpu.memoryReadByte(r.Get()), bit, cpu.FlagC()))
+	return 12
+}
+
+func (cpu *CPU) resR8(bit uint8, r *ByteRegister) int {
+	r.Set(r.Get() &^ (1 << bit))
+	return 8
+}
+
+func (cpu *CPU) resaR16(bit uint8, r *WordRegister) int {
+	d8 := cpu.memoryReadByte(r.Get())
+	cpu.memoryWriteByte(r.Get(), d8&^(1<<bit))
+	return 16
+}
+
+func (cpu *CPU) setR8(bit uint8, r *ByteRegister) int {
+	r.Set(r.Get() | (1 << bit))
+	return 8
+}
+
+func (cpu *CPU) setaR16(bit uint8, r *WordRegister) int {
+	d8 := cpu.memoryReadByte(r.Get())
+	cpu.memoryWriteByte(r.Get(), d8|(1<<bit))
+	return 16
+}
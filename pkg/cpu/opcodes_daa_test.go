@@ -0,0 +1,95 @@
+package cpu_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorkaio/gboy/pkg/cpu"
+	mocks "github.com/gorkaio/gboy/pkg/cpu/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDAAAdjustsAccumulatorForEveryInput(t *testing.T) {
+	forEachAccumulatorInput(func(a, flags int) {
+		subtract := flags&FlagN != 0
+		correction := 0
+		carry := flags&FlagC != 0
+		if flags&FlagH != 0 || (!subtract && a&0x0F > 0x09) {
+			correction |= 0x06
+		}
+		if carry || (!subtract && a > 0x99) {
+			correction |= 0x60
+			carry = true
+		}
+
+		result := (a + correction) & 0xFF
+		if subtract {
+			result = (a - correction) & 0xFF
+		}
+
+		expFlags := flags & FlagN
+		if result == 0 {
+			expFlags |= FlagZ
+		}
+		if carry {
+			expFlags |= FlagC
+		}
+
+		testDescription := testDescription{
+			fmt.Sprintf("'DAA' on A=%#02x with flags %#02x results in %#02x with flags %#02x", a, flags, result, expFlags),
+			opcode{0x27},
+			regMap{"A": a, "F": flags},
+			regMap{"A": result, "F": expFlags},
+			memMap{},
+			memMap{},
+			4,
+		}
+		testCase := buildTestCase(testDescription)
+		testCase.Run(t)
+	})
+}
+
+func TestDAAProducesBCDResultAfterAddAndSub(t *testing.T) {
+	tests := []struct {
+		mnemonic string
+		op       byte
+		bcd      func(x, y int) (int, bool)
+	}{
+		{"ADD A, B", 0x80, func(x, y int) (int, bool) {
+			return (x + y) % 100, x+y > 99
+		}},
+		{"SUB B", 0x90, func(x, y int) (int, bool) {
+			return (x - y + 100) % 100, y > x
+		}},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	for _, test := range tests {
+		ram := map[uint16]byte{0x100: test.op, 0x101: 0x27}
+		mem := mocks.NewMockMemory(ctrl)
+		mem.EXPECT().Read(gomock.Any()).DoAndReturn(func(address uint16) byte {
+			return ram[address]
+		}).AnyTimes()
+		c := cpu.New(mem)
+
+		for x := 0; x < 100; x++ {
+			for y := 0; y < 100; y++ {
+				a := byte(x/10<<4 | x%10)
+				b := byte(y/10<<4 | y%10)
+				c.SetStatus(cpu.State{AF: uint16(a) << 8, BC: uint16(b) << 8, PC: 0x100, SP: 0xFFFE})
+				for i := 0; i < 2; i++ {
+					_, err := c.Step()
+					assert.NoError(t, err)
+				}
+
+				expected, carry := test.bcd(x, y)
+				status := c.Status()
+				assert.Equal(t, expected/10<<4|expected%10, int(status.AF>>8), "'%s; DAA' with %02d and %02d", test.mnemonic, x, y)
+				assert.Equal(t, carry, status.AF&FlagC != 0, "'%s; DAA' carry with %02d and %02d", test.mnemonic, x, y)
+			}
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package cpu_test
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCPLComplementsAccumulator(t *testing.T) {
+	forEachAccumulatorInput(func(a, flags int) {
+		testDescription := testDescription{
+			fmt.Sprintf("'CPL' on A=%#02x with flags %#02x inverts A and sets N and H flags", a, flags),
+			opcode{0x2F},
+			regMap{"A": a, "F": flags},
+			regMap{"A": ^a & 0xFF, "F": flags | FlagN | FlagH},
+			memMap{},
+			memMap{},
+			4,
+		}
+		testCase := buildTestCase(testDescription)
+		testCase.Run(t)
+	})
+}
+
+func TestSCFSetsCarryFlag(t *testing.T) {
+	forEachAccumulatorInput(func(a, flags int) {
+		testDescription := testDescription{
+			fmt.Sprintf("'SCF' on A=%#02x with flags %#02x sets carry, clears N and H and keeps Z", a, flags),
+			opcode{0x37},
+			regMap{"A": a, "F": flags},
+			regMap{"F": flags&FlagZ | FlagC},
+			memMap{},
+			memMap{},
+			4,
+		}
+		testCase := buildTestCase(testDescription)
+		testCase.Run(t)
+	})
+}
+
+func TestCCFComplementsCarryFlag(t *testing.T) {
+	forEachAccumulatorInput(func(a, flags int) {
+		testDescription := testDescription{
+			fmt.Sprintf("'CCF' on A=%#02x with flags %#02x inverts carry, clears N and H and keeps Z", a, flags),
+			opcode{0x3F},
+			regMap{"A": a, "F": flags},
+			regMap{"F": flags&FlagZ | (flags&FlagC ^ FlagC)},
+			memMap{},
+			memMap{},
+			4,
+		}
+		testCase := buildTestCase(testDescription)
+		testCase.Run(t)
+	})
+}
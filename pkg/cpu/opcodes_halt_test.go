@@ -0,0 +1,204 @@
+package cpu_test
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorkaio/gboy/pkg/cpu"
+	mocks "github.com/gorkaio/gboy/pkg/cpu/mocks"
+	"github.com/gorkaio/gboy/pkg/joypad"
+	"github.com/gorkaio/gboy/pkg/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHaltEntersHaltedStateWhenInterruptsEnabled(t *testing.T) {
+	testDescription := testDescription{
+		"HALT enters halted state when IME is set",
+		opcode{0x76},
+		regMap{},
+		regMap{},
+		memMap{0xFF0F: 0xE0, 0xFFFF: 0x00},
+		memMap{},
+		4,
+	}
+	testCase := buildTestCase(testDescription)
+	testCase.WithIME(true)
+	testCase.ExpectHalted(true)
+	testCase.Run(t)
+}
+
+func TestHaltEntersHaltedStateWhenNoInterruptPending(t *testing.T) {
+	testDescription := testDescription{
+		"HALT enters halted state when IME is clear and no interrupt is pending",
+		opcode{0x76},
+		regMap{},
+		regMap{},
+		memMap{0xFFFF: 0x1F, 0xFF0F: 0x00},
+		memMap{},
+		4,
+	}
+	testCase := buildTestCase(testDescription)
+	testCase.ExpectHalted(true)
+	testCase.Run(t)
+}
+
+func TestHaltDoesNotHaltWhenInterruptPendingAndIMEClear(t *testing.T) {
+	testDescription := testDescription{
+		"HALT does not enter halted state when IME is clear and an interrupt is pending",
+		opcode{0x76},
+		regMap{},
+		regMap{},
+		memMap{0xFFFF: 0x04, 0xFF0F: 0x04},
+		memMap{},
+		4,
+	}
+	testCase := buildTestCase(testDescription)
+	testCase.Run(t)
+}
+
+func TestHaltedCPUBurnsCyclesUntilInterruptPending(t *testing.T) {
+	tests := []struct {
+		description  string
+		ie, ifr      byte
+		expectHalted bool
+	}{
+		{"Halted CPU stays halted when no interrupt is requested", 0x1F, 0x00, true},
+		{"Halted CPU stays halted when requested interrupt is not enabled", 0x01, 0x02, true},
+		{"Halted CPU wakes up when an enabled interrupt is requested", 0x02, 0x02, false},
+	}
+
+	for _, test := range tests {
+		testCase := NewTestCase([]byte{})
+		testCase.WithDescription(test.description)
+		testCase.WithRegister("PC", 0x200)
+		testCase.WithHalted(true)
+		testCase.ExpectHalted(test.expectHalted)
+		testCase.ExpectMemoryRead(0xFFFF, test.ie)
+		testCase.ExpectMemoryRead(0xFF0F, test.ifr)
+		if !test.expectHalted {
+			testCase.ExpectMemoryRead(0x200, 0x00)
+			testCase.ExpectRegister("PC", 0x201)
+		}
+		testCase.ExpectCycles(4)
+		testCase.Run(t)
+	}
+}
+
+func TestHaltBugExecutesNextByteTwice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ram := map[uint16]byte{
+		0x100:  0x76, // HALT
+		0x101:  0x04, // INC B
+		0x102:  0x00, // NOP
+		0xFFFF: 0x01,
+		0xFF0F: 0x01,
+	}
+	mem := mocks.NewMockMemory(ctrl)
+	mem.EXPECT().Read(gomock.Any()).DoAndReturn(func(address uint16) byte {
+		return ram[address]
+	}).AnyTimes()
+
+	c := cpu.New(mem)
+	for i := 0; i < 3; i++ {
+		_, err := c.Step()
+		assert.NoError(t, err)
+	}
+
+	status := c.Status()
+	assert.False(t, status.Halted)
+	assert.Equal(t, uint16(0x0200), status.BC)
+	assert.Equal(t, uint16(0x102), status.PC)
+}
+
+func TestHaltBugRereadsOpcodeAsOperand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ram := map[uint16]byte{
+		0x100:  0x76, // HALT
+		0x101:  0x3E, // LD A, 0x14
+		0x102:  0x14, // INC D
+		0xFFFF: 0x01,
+		0xFF0F: 0x01,
+	}
+	mem := mocks.NewMockMemory(ctrl)
+	mem.EXPECT().Read(gomock.Any()).DoAndReturn(func(address uint16) byte {
+		return ram[address]
+	}).AnyTimes()
+
+	c := cpu.New(mem)
+	for i := 0; i < 3; i++ {
+		_, err := c.Step()
+		assert.NoError(t, err)
+	}
+
+	status := c.Status()
+	assert.Equal(t, uint16(0x3E00), status.AF&0xFF00)
+	assert.Equal(t, uint16(0x0100), status.DE)
+	assert.Equal(t, uint16(0x103), status.PC)
+}
+
+func TestStopEntersStoppedState(t *testing.T) {
+	testDescription := testDescription{
+		"STOP enters stopped state skipping its padding byte",
+		opcode{0x10},
+		regMap{},
+		regMap{"PC": 0x02},
+		memMap{},
+		memMap{},
+		4,
+	}
+	testCase := buildTestCase(testDescription)
+	testCase.ExpectStopped(true)
+	testCase.Run(t)
+}
+
+func TestStoppedCPUWakesUpOnJoypadPress(t *testing.T) {
+	tests := []struct {
+		description   string
+		joypad        byte
+		expectStopped bool
+	}{
+		{"Stopped CPU stays stopped when no button is pressed", 0xEF, true},
+		{"Stopped CPU wakes up when a button is pressed", 0xEE, false},
+	}
+
+	for _, test := range tests {
+		testCase := NewTestCase([]byte{})
+		testCase.WithDescription(test.description)
+		testCase.WithRegister("PC", 0x200)
+		testCase.WithStopped(true)
+		testCase.ExpectStopped(test.expectStopped)
+		testCase.ExpectMemoryRead(0xFF00, test.joypad)
+		if !test.expectStopped {
+			testCase.ExpectMemoryRead(0x200, 0x00)
+			testCase.ExpectRegister("PC", 0x201)
+		}
+		testCase.ExpectCycles(4)
+		testCase.Run(t)
+	}
+}
+
+func TestStoppedCPUWakesUpOnJoypadPressThroughMemory(t *testing.T) {
+	mem := memory.New()
+	mem.Write(0xC000, 0x10) // STOP
+	mem.Write(0xC002, 0x04) // INC B
+	mem.Write(joypad.Addr, 0x20)
+
+	c := cpu.New(mem)
+	c.PC.Set(0xC000)
+	for i := 0; i < 3; i++ {
+		_, err := c.Step()
+		assert.NoError(t, err)
+	}
+	assert.True(t, c.Status().Stopped)
+
+	mem.Joypad().Press(joypad.Up)
+	_, err := c.Step()
+	assert.NoError(t, err)
+	assert.False(t, c.Status().Stopped)
+	assert.Equal(t, uint16(0x0100), c.Status().BC)
+	assert.Equal(t, uint16(0xC003), c.Status().PC)
+}
@@ -4,9 +4,9 @@ import (
 	"testing"
 )
 
-func TestEIEnablesInterrupts(t *testing.T) {
+func TestEIEnablesInterruptsAfterNextInstruction(t *testing.T) {
 	testDescription := testDescription{
-		"EI enables interrupts",
+		"EI schedules interrupts to be enabled after the next instruction",
 		opcode{0xFB},
 		regMap{},
 		regMap{},
@@ -16,17 +16,35 @@ func TestEIEnablesInterrupts(t *testing.T) {
 	}
 	testCase := buildTestCase(testDescription)
 	testCase.WithIME(false)
+	testCase.ExpectIME(false)
+	testCase.ExpectIMEScheduled(true)
+	testCase.Run(t)
+}
+
+func TestInstructionAfterEIEnablesInterrupts(t *testing.T) {
+	testDescription := testDescription{
+		"Instruction following EI enables interrupts once executed",
+		opcode{0x00},
+		regMap{},
+		regMap{},
+		memMap{},
+		memMap{},
+		4,
+	}
+	testCase := buildTestCase(testDescription)
+	testCase.WithIMEScheduled(true)
+	testCase.ExpectIMEScheduled(false)
 	testCase.ExpectIME(true)
 	testCase.Run(t)
 }
 
 func TestDIDisablesInterrupts(t *testing.T) {
 	testDescription := testDescription{
-		"EI enables interrupts",
+		"DI disables interrupts",
 		opcode{0xF3},
 		regMap{},
 		regMap{},
-		memMap{},
+		memMap{0xFF0F: 0xE0, 0xFFFF: 0x00},
 		memMap{},
 		4,
 	}
@@ -35,3 +53,20 @@ func TestDIDisablesInterrupts(t *testing.T) {
 	testCase.ExpectIME(false)
 	testCase.Run(t)
 }
+
+func TestDICancelsScheduledEI(t *testing.T) {
+	testDescription := testDescription{
+		"DI right after EI keeps interrupts disabled",
+		opcode{0xF3},
+		regMap{},
+		regMap{},
+		memMap{},
+		memMap{},
+		4,
+	}
+	testCase := buildTestCase(testDescription)
+	testCase.WithIMEScheduled(true)
+	testCase.ExpectIMEScheduled(false)
+	testCase.ExpectIME(false)
+	testCase.Run(t)
+}
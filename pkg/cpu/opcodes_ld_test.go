@@ -219,11 +219,38 @@ func TestLoadIndirectOther(t *testing.T) {
 			12,
 		},
 		{
-			"'LDHL SP, r8' loads memory address content (SP + r8) into HL. Clears Z and N flags.",
+			"'LDHL SP, r8' loads SP + r8 into HL. Clears Z and N flags.",
 			opcode{0xF8, 0x12},
 			regMap{"SP": 0x1234, "F": FlagZ | FlagN},
-			regMap{"HL": 0x5678, "F": 0},
-			memMap{0x1246: 0x78, 0x1247: 0x56},
+			regMap{"HL": 0x1246, "F": 0},
+			memMap{},
+			memMap{},
+			12,
+		},
+		{
+			"'LDHL SP, r8' sets H and C from the addition to the low byte of SP",
+			opcode{0xF8, 0x01},
+			regMap{"SP": 0x12FF},
+			regMap{"HL": 0x1300, "F": FlagH | FlagC},
+			memMap{},
+			memMap{},
+			12,
+		},
+		{
+			"'LDHL SP, r8' adds negative r8 to SP",
+			opcode{0xF8, 0xFF},
+			regMap{"SP": 0x1234},
+			regMap{"HL": 0x1233, "F": FlagH | FlagC},
+			memMap{},
+			memMap{},
+			12,
+		},
+		{
+			"'LDHL SP, r8' clears H and C when negative r8 does not carry out of the low byte",
+			opcode{0xF8, 0xFF},
+			regMap{"SP": 0x1200, "F": FlagH | FlagC},
+			regMap{"HL": 0x11FF, "F": 0},
+			memMap{},
 			memMap{},
 			12,
 		},
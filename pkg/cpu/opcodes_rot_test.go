@@ -0,0 +1,155 @@
+package cpu_test
+
+import (
+	"fmt"
+	"testing"
+)
+
+var cbRegisterOffsets = map[string]byte{
+	"B": 0x00, "C": 0x01, "D": 0x02, "E": 0x03,
+	"H": 0x04, "L": 0x05, "A": 0x07,
+}
+
+func TestRotateAndShiftRegisters(t *testing.T) {
+	tests := []struct {
+		mnemonic string
+		base     byte
+		value    int
+		flags    int
+		result   int
+		expFlags int
+	}{
+		{"RLC", 0x00, 0x85, 0, 0x0B, FlagC},
+		{"RLC", 0x00, 0x00, FlagC | FlagN | FlagH, 0x00, FlagZ},
+		{"RLC", 0x00, 0x40, FlagC, 0x80, 0},
+		{"RRC", 0x08, 0x01, 0, 0x80, FlagC},
+		{"RRC", 0x08, 0x00, FlagC, 0x00, FlagZ},
+		{"RRC", 0x08, 0x02, FlagC, 0x01, 0},
+		{"RL", 0x10, 0x80, 0, 0x00, FlagZ | FlagC},
+		{"RL", 0x10, 0x11, FlagC, 0x23, 0},
+		{"RL", 0x10, 0x80, FlagC, 0x01, FlagC},
+		{"RR", 0x18, 0x01, 0, 0x00, FlagZ | FlagC},
+		{"RR", 0x18, 0x8A, FlagC, 0xC5, 0},
+		{"RR", 0x18, 0x01, FlagC, 0x80, FlagC},
+		{"SLA", 0x20, 0x80, 0, 0x00, FlagZ | FlagC},
+		{"SLA", 0x20, 0xFF, 0, 0xFE, FlagC},
+		{"SLA", 0x20, 0x01, FlagC, 0x02, 0},
+		{"SRA", 0x28, 0x8A, 0, 0xC5, 0},
+		{"SRA", 0x28, 0x01, 0, 0x00, FlagZ | FlagC},
+		{"SRA", 0x28, 0x81, FlagN | FlagH, 0xC0, FlagC},
+		{"SWAP", 0x30, 0x00, FlagC | FlagN | FlagH, 0x00, FlagZ},
+		{"SWAP", 0x30, 0xF0, FlagC, 0x0F, 0},
+		{"SWAP", 0x30, 0x12, 0, 0x21, 0},
+		{"SRL", 0x38, 0x01, 0, 0x00, FlagZ | FlagC},
+		{"SRL", 0x38, 0xFF, 0, 0x7F, FlagC},
+		{"SRL", 0x38, 0x80, FlagC, 0x40, 0},
+	}
+
+	for _, test := range tests {
+		for r8, offset := range cbRegisterOffsets {
+			testDescription := testDescription{
+				fmt.Sprintf("'%s %s' on %#02x with flags %#02x results in %#02x with flags %#02x", test.mnemonic, r8, test.value, test.flags, test.result, test.expFlags),
+				opcode{0xCB, test.base + offset},
+				regMap{r8: test.value, "F": test.flags},
+				regMap{r8: test.result, "F": test.expFlags},
+				memMap{},
+				memMap{},
+				8,
+			}
+			testCase := buildTestCase(testDescription)
+			testCase.Run(t)
+		}
+	}
+}
+
+func TestRotateAndShiftIndirect(t *testing.T) {
+	tests := []struct {
+		mnemonic string
+		opc      opcode
+		value    uint8
+		flags    int
+		result   uint8
+		expFlags int
+	}{
+		{"RLC", opcode{0xCB, 0x06}, 0x85, 0, 0x0B, FlagC},
+		{"RRC", opcode{0xCB, 0x0E}, 0x01, 0, 0x80, FlagC},
+		{"RL", opcode{0xCB, 0x16}, 0x11, FlagC, 0x23, 0},
+		{"RR", opcode{0xCB, 0x1E}, 0x01, 0, 0x00, FlagZ | FlagC},
+		{"SLA", opcode{0xCB, 0x26}, 0xFF, 0, 0xFE, FlagC},
+		{"SRA", opcode{0xCB, 0x2E}, 0x8A, 0, 0xC5, 0},
+		{"SWAP", opcode{0xCB, 0x36}, 0xF0, FlagC, 0x0F, 0},
+		{"SRL", opcode{0xCB, 0x3E}, 0x01, 0, 0x00, FlagZ | FlagC},
+	}
+
+	for _, test := range tests {
+		testDescription := testDescription{
+			fmt.Sprintf("'%s (HL)' on %#02x results in %#02x written back to (HL)", test.mnemonic, test.value, test.result),
+			test.opc,
+			regMap{"HL": 0x1234, "F": test.flags},
+			regMap{"F": test.expFlags},
+			memMap{0x1234: test.value},
+			memMap{0x1234: test.result},
+			16,
+		}
+		testCase := buildTestCase(testDescription)
+		testCase.Run(t)
+	}
+}
+
+func forEachAccumulatorInput(f func(a, flags int)) {
+	for a := 0; a <= 0xFF; a++ {
+		for flags := 0; flags <= 0xF0; flags += 0x10 {
+			f(a, flags)
+		}
+	}
+}
+
+func TestRotateAccumulator(t *testing.T) {
+	tests := []struct {
+		mnemonic string
+		opc      opcode
+		rotate   func(a int, carry bool) (int, bool)
+	}{
+		{"RLCA", opcode{0x07}, func(a int, carry bool) (int, bool) {
+			return (a<<1 | a>>7) & 0xFF, a&0x80 != 0
+		}},
+		{"RRCA", opcode{0x0F}, func(a int, carry bool) (int, bool) {
+			return (a>>1 | a<<7) & 0xFF, a&0x01 != 0
+		}},
+		{"RLA", opcode{0x17}, func(a int, carry bool) (int, bool) {
+			result := a << 1
+			if carry {
+				result |= 0x01
+			}
+			return result & 0xFF, a&0x80 != 0
+		}},
+		{"RRA", opcode{0x1F}, func(a int, carry bool) (int, bool) {
+			result := a >> 1
+			if carry {
+				result |= 0x80
+			}
+			return result, a&0x01 != 0
+		}},
+	}
+
+	for _, test := range tests {
+		forEachAccumulatorInput(func(a, flags int) {
+			result, carry := test.rotate(a, flags&FlagC != 0)
+			expFlags := 0
+			if carry {
+				expFlags = FlagC
+			}
+			testDescription := testDescription{
+				fmt.Sprintf("'%s' on A=%#02x with flags %#02x results in %#02x with flags %#02x", test.mnemonic, a, flags, result, expFlags),
+				test.opc,
+				regMap{"A": a, "F": flags},
+				regMap{"A": result, "F": expFlags},
+				memMap{},
+				memMap{},
+				4,
+			}
+			testCase := buildTestCase(testDescription)
+			testCase.Run(t)
+		})
+	}
+}
@@ -2,6 +2,8 @@ package cpu_test
 
 import (
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestRst(t *testing.T) {
@@ -26,10 +28,28 @@ func TestRst(t *testing.T) {
 			regMap{"SP": 0x1234, "PC": 0x5678},
 			regMap{"SP": 0x1232, "PC": test.value},
 			memMap{},
-			memMap{0x1233: 0x56, 0x1232: 0x78},
+			memMap{0x1233: 0x56, 0x1232: 0x79},
 			16,
 		}
 		testCase := buildTestCase(testDescription)
 		testCase.Run(t)
 	}
-}
\ No newline at end of file
+}
+
+func TestRstReturnsToTheNextInstruction(t *testing.T) {
+	ram := map[uint16]byte{
+		0x100: 0xEF, // RST 28H
+		0x101: 0x04, // INC B
+		0x28:  0xC9, // RET
+	}
+	c, ctrl := newRAMBackedCPU(t, ram)
+	defer ctrl.Finish()
+
+	for i := 0; i < 3; i++ {
+		_, err := c.Step()
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, uint16(0x102), c.Status().PC)
+	assert.Equal(t, uint16(0x0100), c.Status().BC)
+	assert.Equal(t, uint16(0xFFFE), c.Status().SP)
+}
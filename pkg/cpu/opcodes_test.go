@@ -31,10 +31,8 @@ func readInstruction(mem *mocks.MockMemory, address uint16, instruction []byte)
 }
 
 func instruction(bytes ...byte) []byte {
-	result := make([]byte, 4)
-	for i, byte := range bytes {
-		result[i] = byte
-	}
+	result := make([]byte, len(bytes))
+	copy(result, bytes)
 	return result
 }
 
@@ -10,8 +10,11 @@ import (
 )
 
 type testState struct {
-	regs map[string]uint16
-	ime  bool
+	regs         map[string]uint16
+	ime          bool
+	imeScheduled bool
+	halted       bool
+	stopped      bool
 }
 
 type memoryAccess struct {
@@ -37,7 +40,10 @@ func NewTestState() testState {
 			"SP": 0,
 			"PC": 0,
 		},
-		ime: false,
+		ime:          false,
+		imeScheduled: false,
+		halted:       false,
+		stopped:      false,
 	}
 }
 
@@ -63,19 +69,25 @@ func NewTestStateFromCPU(c cpu.State) testState {
 			"SP": c.SP,
 			"PC": c.PC,
 		},
-		ime: c.IME,
+		ime:          c.IME,
+		imeScheduled: c.IMEScheduled,
+		halted:       c.Halted,
+		stopped:      c.Stopped,
 	}
 }
 
 func (t *testState) toCPUState() cpu.State {
 	return cpu.State{
-		AF:  t.regs["AF"],
-		BC:  t.regs["BC"],
-		DE:  t.regs["DE"],
-		HL:  t.regs["HL"],
-		SP:  t.regs["SP"],
-		PC:  t.regs["PC"],
-		IME: t.ime,
+		AF:           t.regs["AF"],
+		BC:           t.regs["BC"],
+		DE:           t.regs["DE"],
+		HL:           t.regs["HL"],
+		SP:           t.regs["SP"],
+		PC:           t.regs["PC"],
+		IME:          t.ime,
+		IMEScheduled: t.imeScheduled,
+		Halted:       t.halted,
+		Stopped:      t.stopped,
 	}
 }
 
@@ -142,6 +154,33 @@ func (t *testCase) ExpectIME(ime bool) {
 	t.expectedState.ime = ime
 }
 
+func (t *testCase) WithIMEScheduled(scheduled bool) {
+	t.initialState.imeScheduled = scheduled
+	t.expectedState.imeScheduled = scheduled
+}
+
+func (t *testCase) ExpectIMEScheduled(scheduled bool) {
+	t.expectedState.imeScheduled = scheduled
+}
+
+func (t *testCase) WithHalted(halted bool) {
+	t.initialState.halted = halted
+	t.expectedState.halted = halted
+}
+
+func (t *testCase) ExpectHalted(halted bool) {
+	t.expectedState.halted = halted
+}
+
+func (t *testCase) WithStopped(stopped bool) {
+	t.initialState.stopped = stopped
+	t.expectedState.stopped = stopped
+}
+
+func (t *testCase) ExpectStopped(stopped bool) {
+	t.expectedState.stopped = stopped
+}
+
 func (t *testCase) ExpectCycles(cycles int) {
 	t.expectedCycles = cycles
 }
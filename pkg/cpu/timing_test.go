@@ -0,0 +1,119 @@
+package cpu_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gorkaio/gboy/pkg/cpu"
+	"github.com/stretchr/testify/assert"
+)
+
+type busAccess struct {
+	write   bool
+	address uint16
+	cycle   int
+}
+
+// timedMemory records the machine cycle in which every bus access happens
+type timedMemory struct {
+	benchMemory
+	cycles   int
+	accesses []busAccess
+}
+
+func (m *timedMemory) tick(cycles int) {
+	m.cycles += cycles
+}
+
+func (m *timedMemory) Read(address uint16) byte {
+	m.accesses = append(m.accesses, busAccess{false, address, m.cycles})
+	return m.benchMemory.Read(address)
+}
+
+func (m *timedMemory) Write(address uint16, data byte) {
+	m.accesses = append(m.accesses, busAccess{true, address, m.cycles})
+	m.benchMemory.Write(address, data)
+}
+
+func newTimedCPU(instruction ...byte) (*cpu.CPU, *timedMemory) {
+	mem := &timedMemory{}
+	copy(mem.benchMemory[0x100:], instruction)
+	c := cpu.New(mem)
+	c.SetTicker(mem.tick)
+	state := c.Status()
+	state.HL = 0xC000
+	state.SP = 0xDFF0
+	c.SetStatus(state)
+	return c, mem
+}
+
+func TestTicksEveryCycleOfEveryInstruction(t *testing.T) {
+	for _, prefix := range [][]byte{{}, {0xCB}} {
+		for opCode := 0; opCode <= 0xFF; opCode++ {
+			for _, flags := range []uint16{0x00, 0xF0} {
+				instruction := append(append([]byte{}, prefix...), byte(opCode), 0x00, 0xC0)
+				c, mem := newTimedCPU(instruction...)
+				state := c.Status()
+				state.AF = flags
+				c.SetStatus(state)
+
+				cycles, err := c.Step()
+				if err != nil {
+					continue
+				}
+				description := fmt.Sprintf("Opcode % x with flags %#02x", instruction[:len(prefix)+1], flags)
+				assert.Equal(t, cycles, mem.cycles, "%s ticks the cycles it consumes", description)
+				busAccesses := 0
+				for _, access := range mem.accesses {
+					// IE and IF are read internally by the CPU without a bus cycle
+					if access.address != 0xFF0F && access.address != 0xFFFF {
+						busAccesses++
+					}
+				}
+				assert.True(t, busAccesses*4 <= cycles, "%s has %d bus accesses in %d cycles", description, busAccesses, cycles)
+			}
+		}
+	}
+}
+
+func TestMemoryAccessesHappenInTheirMachineCycle(t *testing.T) {
+	tests := []struct {
+		description string
+		instruction []byte
+		accesses    []busAccess
+	}{
+		{
+			"'LD (a16), A' writes on its fourth machine cycle",
+			[]byte{0xEA, 0x34, 0xC2},
+			[]busAccess{{false, 0x100, 4}, {false, 0x101, 8}, {false, 0x102, 12}, {true, 0xC234, 16}},
+		},
+		{
+			"'INC (HL)' reads on its second and writes on its third machine cycle",
+			[]byte{0x34},
+			[]busAccess{{false, 0x100, 4}, {false, 0xC000, 8}, {true, 0xC000, 12}},
+		},
+		{
+			"'PUSH BC' writes after an internal cycle, high byte first",
+			[]byte{0xC5},
+			[]busAccess{{false, 0x100, 4}, {true, 0xDFEF, 12}, {true, 0xDFEE, 16}},
+		},
+		{
+			"'CALL a16' pushes after reading its operand and an internal cycle",
+			[]byte{0xCD, 0x34, 0x12},
+			[]busAccess{{false, 0x100, 4}, {false, 0x101, 8}, {false, 0x102, 12}, {true, 0xDFEF, 20}, {true, 0xDFEE, 24}},
+		},
+		{
+			"'SET 0, (HL)' reads on its third and writes on its fourth machine cycle",
+			[]byte{0xCB, 0xC6},
+			[]busAccess{{false, 0x100, 4}, {false, 0x101, 8}, {false, 0xC000, 12}, {true, 0xC000, 16}},
+		},
+	}
+
+	for _, test := range tests {
+		c, mem := newTimedCPU(test.instruction...)
+		// Accesses are recorded once the cycle they belong to has been ticked
+		_, err := c.Step()
+		assert.NoError(t, err)
+		assert.Equal(t, test.accesses, mem.accesses, test.description)
+	}
+}
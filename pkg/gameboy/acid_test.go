@@ -0,0 +1,77 @@
+package gameboy_test
+
+import (
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gorkaio/gboy/pkg/cpu"
+	"github.com/gorkaio/gboy/pkg/gameboy"
+	gbmemory "github.com/gorkaio/gboy/pkg/memory"
+	"github.com/gorkaio/gboy/pkg/ppu"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDMGAcid2 runs Matt Currie's dmg-acid2 and compares the screen with its
+// DMG reference image. make testdata downloads both into testdata.
+func TestDMGAcid2(t *testing.T) {
+	romfile := filepath.Join("testdata", "dmg-acid2.gb")
+	reference, err := readReference(filepath.Join("testdata", "reference-dmg.png"))
+	if os.IsNotExist(err) && os.Getenv("CI") == "" {
+		t.Skip("dmg-acid2 not found in testdata, run make testdata")
+	}
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	renderers := map[string]ppu.Renderer{
+		"scanline": ppu.ScanlineRenderer,
+		"fifo":     ppu.FIFORenderer,
+	}
+	for name, renderer := range renderers {
+		t.Run(name, func(t *testing.T) {
+			m := gbmemory.New()
+			gb, err := gameboy.New(m, cpu.New(m), renderer)
+			assert.NoError(t, err)
+			assert.NoError(t, gb.LoadCart(romfile))
+			for i := 0; i < 60; i++ {
+				assert.NoError(t, gb.Update())
+			}
+
+			frame := gb.Frame()
+			mismatches := 0
+			for y := 0; y < ppu.ScreenHeight; y++ {
+				for x := 0; x < ppu.ScreenWidth; x++ {
+					if frame[y][x] != reference[y][x] {
+						mismatches++
+					}
+				}
+			}
+			assert.Equal(t, 0, mismatches, "Pixels differing from the reference image")
+		})
+	}
+}
+
+// readReference reads a 160x144 grayscale image as DMG shades
+func readReference(filename string) (*ppu.Frame, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	img, err := png.Decode(file)
+	if err != nil {
+		return nil, err
+	}
+
+	frame := &ppu.Frame{}
+	for y := 0; y < ppu.ScreenHeight; y++ {
+		for x := 0; x < ppu.ScreenWidth; x++ {
+			gray := color.GrayModel.Convert(img.At(x, y)).(color.Gray)
+			frame[y][x] = 3 - byte((int(gray.Y)+42)/85)
+		}
+	}
+	return frame, nil
+}
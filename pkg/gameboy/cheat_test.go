@@ -0,0 +1,59 @@
+package gameboy_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gorkaio/gboy/pkg/ppu"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadCartAppliesCheatFile(t *testing.T) {
+	gb, memory, c, dir := newSaveTest(t)
+	defer os.RemoveAll(dir)
+	romfile := writeROM(t, dir, 0x00, 0x00)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "game.cht"), []byte("C91-23E Genie\n01FF42C0 Shark\n"), 0644))
+
+	cart := expectLoad(memory)
+	assert.NoError(t, gb.LoadCart(romfile))
+	assert.Equal(t, byte(0xC9), (*cart).Read(0x1123))
+	assert.Len(t, gb.Cheats().Cheats(), 2)
+
+	c.EXPECT().Step().Return(ppu.DotsPerFrame, nil)
+	memory.EXPECT().Write(uint16(0xC042), uint8(0xFF))
+	assert.NoError(t, gb.Update())
+
+	assert.NoError(t, gb.Cheats().Disable("C91-23E"))
+	assert.Equal(t, byte(0x00), (*cart).Read(0x1123))
+}
+
+func TestLoadCartFailsOnMalformedCheatFile(t *testing.T) {
+	gb, _, _, dir := newSaveTest(t)
+	defer os.RemoveAll(dir)
+	romfile := writeROM(t, dir, 0x00, 0x00)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "game.cht"), []byte("C91-23E\nC91-2\n"), 0644))
+
+	err := gb.LoadCart(romfile)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `Line 2: Invalid cheat code "C91-2"`)
+}
+
+func TestEjectDropsTheCartCheats(t *testing.T) {
+	gb, memory, c, dir := newSaveTest(t)
+	defer os.RemoveAll(dir)
+	romfile := writeROM(t, dir, 0x00, 0x00)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "game.cht"), []byte("01FF42C0 Shark\n"), 0644))
+
+	expectLoad(memory)
+	assert.NoError(t, gb.LoadCart(romfile))
+	assert.Len(t, gb.Cheats().Cheats(), 1)
+
+	memory.EXPECT().Eject()
+	assert.NoError(t, gb.Eject())
+	assert.Empty(t, gb.Cheats().Cheats())
+
+	c.EXPECT().Step().Return(ppu.DotsPerFrame, nil)
+	assert.NoError(t, gb.Update(), "GameShark codes are not written with no cart")
+}
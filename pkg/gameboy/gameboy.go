@@ -3,15 +3,20 @@ package gameboy
 import (
 	"fmt"
 	"github.com/gorkaio/gboy/pkg/cart"
+	"github.com/gorkaio/gboy/pkg/cheat"
+	"github.com/gorkaio/gboy/pkg/cpu"
+	"github.com/gorkaio/gboy/pkg/interrupts"
 	"github.com/gorkaio/gboy/pkg/memory"
-	"io/ioutil"
+	"github.com/gorkaio/gboy/pkg/patch"
+	"github.com/gorkaio/gboy/pkg/ppu"
+	"os"
+	"time"
 )
 
 //go:generate mockgen -destination=mocks/memory_mock.go -package=gameboy_mock github.com/gorkaio/gboy/pkg/gameboy Memory
 //go:generate mockgen -destination=mocks/cpu_mock.go -package=gameboy_mock github.com/gorkaio/gboy/pkg/gameboy CPU
 
-const cyclesPerScanline = 456
-const cyclesPerFrame = 69905
+const clockSpeed = 4194304
 
 // Memory defines the interface for memory interaction
 type Memory interface {
@@ -19,86 +24,225 @@ type Memory interface {
 	Eject()
 	Read(address uint16) uint8
 	Write(address uint16, data uint8)
+	ReadVideo(address uint16) uint8
+	Map(device memory.Device, low, high uint16, readMasks ...byte) error
+	Interrupts() *interrupts.Controller
+	RAMBank() int
+	Tick(cycles int)
 }
 
 // CPU defines the interface for CPU interaction
 type CPU interface {
 	Step() (int, error)
+	SetTicker(ticker cpu.Ticker)
 }
 
 // Gameboy struct
 type Gameboy struct {
-	cpu             CPU
-	mem             Memory
-	romfile         string
-	scanlineCounter int
-	paused          bool
+	cpu          CPU
+	mem          Memory
+	ppu          *ppu.PPU
+	romfile      string
+	cart         *cart.Cart
+	cheats       *cheat.Engine
+	rumble       cart.Rumble
+	savefile     string
+	saved        []byte
+	saveInterval time.Duration
+	sinceSave    time.Duration
+	paused       bool
+	vblank       bool
 }
 
-// New initialises a new Gameboy System
-func New(mem Memory, cpu CPU) (*Gameboy, error) {
+// New initialises a new Gameboy System, drawing the screen with the given renderer
+func New(mem Memory, cpu CPU, renderer ppu.Renderer) (*Gameboy, error) {
 	gameboy := &Gameboy{
-		mem:             mem,
-		cpu:             cpu,
-		scanlineCounter: cyclesPerScanline,
-		paused:          false,
+		mem:          mem,
+		cpu:          cpu,
+		ppu:          ppu.New(mem.Interrupts(), mem, renderer),
+		paused:       false,
+		saveInterval: DefaultSaveInterval,
+		cheats:       cheat.NewEngine(),
 	}
+	err := mem.Map(gameboy.ppu, ppu.LCDCAddr, ppu.LYCAddr)
+	if err != nil {
+		return nil, err
+	}
+	err = mem.Map(gameboy.ppu, ppu.BGPAddr, ppu.OBP1Addr)
+	if err != nil {
+		return nil, err
+	}
+	err = mem.Map(gameboy.ppu, ppu.WYAddr, ppu.WXAddr)
+	if err != nil {
+		return nil, err
+	}
+	cpu.SetTicker(gameboy.tick)
 
 	return gameboy, nil
 }
 
-// LoadCart loads a cart using the loader
-func (gb *Gameboy) LoadCart(romfile string) error {
-	data, err := ioutil.ReadFile(romfile)
+// LoadCart loads a cart from a ROM file, which may be zipped or gzipped.
+// The given IPS, UPS or BPS patches are applied in memory, or else a patch
+// named like the ROM next to it. Battery-backed RAM is kept in a .sav file next to it.
+func (gb *Gameboy) LoadCart(romfile string, patchfiles ...string) error {
+	if len(patchfiles) == 0 {
+		patchfiles = findPatch(romfile)
+	}
+	patches := []cart.Source{}
+	for _, patchfile := range patchfiles {
+		patches = append(patches, cart.FromFile(patchfile))
+	}
+
+	cheats, err := loadCheats(romfile)
 	if err != nil {
 		return err
 	}
 
-	cart, err := cart.NewCart(data)
+	err = gb.Load(patch.Patched(cart.FromFile(romfile), patches...), savePath(romfile))
 	if err != nil {
 		return err
 	}
-
-	gb.mem.Load(cart)
+	gb.cheats = cheats
 	gb.romfile = romfile
 	return nil
 }
 
-// Eject ejects a cart from memory
-func (gb *Gameboy) Eject() {
+// Load loads a cart from any ROM source. Battery-backed RAM is kept in
+// savefile, or lost on eject if it is empty.
+func (gb *Gameboy) Load(source cart.Source, savefile string) error {
+	c, err := cart.Load(source)
+	if err != nil {
+		return err
+	}
+
+	// Keep the progress of the cart being replaced
+	err = gb.Flush()
+	if err != nil {
+		return err
+	}
+
+	var saved []byte
+	if c.Type().Battery {
+		saved, err = loadSave(c, savefile)
+		if err != nil {
+			return err
+		}
+	}
+
+	gb.cart = c
+	gb.cheats = cheat.NewEngine()
+	c.SetReadFilter(gb.readROM)
+	c.SetRumble(gb.rumble)
+	gb.savefile = savefile
+	gb.saved = saved
+	gb.sinceSave = 0
+	gb.mem.Load(c)
+	return nil
+}
+
+// Frame returns the last frame drawn to the screen
+func (gb *Gameboy) Frame() *ppu.Frame {
+	return gb.ppu.Frame()
+}
+
+// SetRumble sets the handler notified when the rumble motor of the loaded
+// cart changes. It carries over to carts loaded afterwards.
+func (gb *Gameboy) SetRumble(rumble cart.Rumble) {
+	gb.rumble = rumble
+	if gb.cart != nil {
+		gb.cart.SetRumble(rumble)
+	}
+}
+
+// Cheats returns the cheat engine for the loaded cart
+func (gb *Gameboy) Cheats() *cheat.Engine {
+	return gb.cheats
+}
+
+// readROM applies Game Genie codes to cart ROM reads
+func (gb *Gameboy) readROM(address uint16, value byte) byte {
+	return gb.cheats.Read(address, value)
+}
+
+// loadCheats reads the .cht cheat file next to the ROM, if there is one
+func loadCheats(romfile string) (*cheat.Engine, error) {
+	cheats := cheat.NewEngine()
+	file, err := os.Open(romBase(romfile) + ".cht")
+	if os.IsNotExist(err) {
+		return cheats, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	err = cheats.Load(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file.Name(), err)
+	}
+	return cheats, nil
+}
+
+// findPatch looks for a patch file named like the ROM next to it
+func findPatch(romfile string) []string {
+	base := romBase(romfile)
+	for _, ext := range patch.Extensions {
+		if _, err := os.Stat(base + ext); err == nil {
+			return []string{base + ext}
+		}
+	}
+	return nil
+}
+
+// Eject flushes battery-backed RAM and ejects the cart from memory, along
+// with its cheats. The cart is ejected even if the flush fails.
+func (gb *Gameboy) Eject() error {
+	err := gb.Flush()
 	gb.romfile = ""
+	gb.cart = nil
+	gb.cheats = cheat.NewEngine()
+	gb.saved = nil
 	gb.mem.Eject()
+	return err
 }
 
-// Run runs the emulation
-func (gb *Gameboy) Run() {
+// Run runs the emulation until it is paused or the CPU fails
+func (gb *Gameboy) Run() error {
 	for !gb.paused {
-		gb.Update()
+		if err := gb.Update(); err != nil {
+			gb.paused = true
+			return err
+		}
 	}
+	return nil
 }
 
-// Update runs the system update cycle for a single frame
-func (gb *Gameboy) Update() {
+// Update runs the system update cycle for a single frame, until the PPU enters
+// VBlank. While the LCD is off it runs for as long as a frame would take.
+func (gb *Gameboy) Update() error {
+	gb.vblank = false
 	cyclesConsumed := 0
-	for cyclesConsumed < cyclesPerFrame {
+	for !gb.vblank && cyclesConsumed < ppu.DotsPerFrame {
 		cycles, err := gb.cpu.Step()
 		if err != nil {
-			fmt.Println(err.Error())
+			return err
 		}
-		gb.updateGraphics(cycles)
 		cyclesConsumed += cycles
 	}
-	// render
+	gb.cheats.Frame(gb.mem)
+
+	gb.sinceSave += time.Duration(cyclesConsumed) * time.Second / clockSpeed
+	if gb.saveInterval > 0 && gb.sinceSave >= gb.saveInterval {
+		return gb.Flush()
+	}
+	return nil
 }
 
-func (gb *Gameboy) updateGraphics(cycles int) {
-	gb.scanlineCounter -= cycles
-	if gb.scanlineCounter <= 0 {
-		currentScanline := gb.mem.Read(0xFF44) + 1
-		if currentScanline > 153 {
-			currentScanline = 0
-		}
-		gb.mem.Write(0xFF44, currentScanline)
-		gb.scanlineCounter = cyclesPerScanline
+// tick advances the rest of the system on every CPU machine cycle
+func (gb *Gameboy) tick(cycles int) {
+	gb.mem.Tick(cycles)
+	mode := gb.ppu.Mode()
+	gb.ppu.Tick(cycles)
+	if mode != ppu.VBlank && gb.ppu.Mode() == ppu.VBlank {
+		gb.vblank = true
 	}
 }
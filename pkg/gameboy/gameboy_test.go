@@ -1,23 +1,144 @@
 package gameboy_test
 
 import (
+	"os"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/gorkaio/gboy/pkg/cpu"
 	"github.com/gorkaio/gboy/pkg/gameboy"
 	mocks "github.com/gorkaio/gboy/pkg/gameboy/mocks"
+	"github.com/gorkaio/gboy/pkg/interrupts"
+	gbmemory "github.com/gorkaio/gboy/pkg/memory"
+	"github.com/gorkaio/gboy/pkg/ppu"
 	"github.com/stretchr/testify/assert"
 )
 
-func TestInitialisesGameboySystem(t *testing.T) {
-	ctrlMemory := gomock.NewController(t)
-	defer ctrlMemory.Finish()
-	memory := mocks.NewMockMemory(ctrlMemory)
+// testGameboy is a Gameboy built on mock memory and CPU, along with the
+// LCD registers and CPU ticker it registered on them
+type testGameboy struct {
+	*gameboy.Gameboy
+	memory *mocks.MockMemory
+	cpu    *mocks.MockCPU
+	irq    *interrupts.Controller
+	lcd    gbmemory.Device
+	ticker cpu.Ticker
+}
 
-	ctrlCPU := gomock.NewController(t)
-	defer ctrlCPU.Finish()
-	cpu := mocks.NewMockCPU(ctrlCPU)
+func newTestGameboy(t *testing.T) *testGameboy {
+	ctrl := gomock.NewController(t)
+	gb := &testGameboy{
+		memory: mocks.NewMockMemory(ctrl),
+		cpu:    mocks.NewMockCPU(ctrl),
+		irq:    interrupts.New(),
+	}
+	gb.memory.EXPECT().Interrupts().Return(gb.irq)
+	gb.memory.EXPECT().Map(gomock.Any(), uint16(0xFF40), uint16(0xFF45)).Do(func(device gbmemory.Device, low, high uint16) {
+		gb.lcd = device
+	})
+	gb.memory.EXPECT().Map(gomock.Any(), uint16(0xFF47), uint16(0xFF49))
+	gb.memory.EXPECT().Map(gomock.Any(), uint16(0xFF4A), uint16(0xFF4B))
+	gb.cpu.EXPECT().SetTicker(gomock.Any()).Do(func(ticker cpu.Ticker) {
+		gb.ticker = ticker
+	})
 
-	_, err := gameboy.New(memory, cpu)
+	var err error
+	gb.Gameboy, err = gameboy.New(gb.memory, gb.cpu, ppu.ScanlineRenderer)
 	assert.NoError(t, err)
+	return gb
+}
+
+func TestInitialisesGameboySystem(t *testing.T) {
+	gb := newTestGameboy(t)
+	assert.NotNil(t, gb.Gameboy)
+	assert.NotNil(t, gb.lcd)
+	assert.NotNil(t, gb.ticker)
+}
+
+func TestUpdateStopsAndReportsCPUErrors(t *testing.T) {
+	gb := newTestGameboy(t)
+	cpuError := &cpu.IllegalOpcodeError{PC: 0x150, Opcode: 0xDD}
+	gb.cpu.EXPECT().Step().Return(4, cpuError).Times(1)
+
+	assert.Equal(t, cpuError, gb.Update())
+}
+
+func TestRunStopsAndReportsCPUErrors(t *testing.T) {
+	gb := newTestGameboy(t)
+	cpuError := &cpu.IllegalOpcodeError{PC: 0x150, Opcode: 0xDD}
+	gb.cpu.EXPECT().Step().Return(4, nil).Times(2)
+	gb.cpu.EXPECT().Step().Return(4, cpuError).Times(1)
+
+	assert.Equal(t, cpuError, gb.Run())
+}
+
+func TestCPUTicksAdvanceThePPU(t *testing.T) {
+	gb := newTestGameboy(t)
+	gb.memory.EXPECT().ReadVideo(gomock.Any()).Return(byte(0xFF)).AnyTimes()
+	gb.memory.EXPECT().Tick(4).Times(144 * 456 / 4)
+
+	assert.Equal(t, byte(0x00), gb.lcd.Read(0xFF44))
+	assert.Equal(t, byte(0), gb.Frame()[0][0])
+	for i := 0; i < 456/4; i++ {
+		gb.ticker(4)
+	}
+	assert.Equal(t, byte(0x01), gb.lcd.Read(0xFF44))
+
+	for i := 0; i < 143*456/4; i++ {
+		gb.ticker(4)
+	}
+	assert.Equal(t, byte(0x90), gb.lcd.Read(0xFF44))
+	assert.Equal(t, byte(0xE1), gb.irq.Read(interrupts.FlagAddr))
+	assert.Equal(t, byte(3), gb.Frame()[0][0], "The frame is presented on VBlank")
+}
+
+func TestUpdateRunsUntilVBlank(t *testing.T) {
+	gb := newTestGameboy(t)
+	gb.memory.EXPECT().ReadVideo(gomock.Any()).Return(byte(0x00)).AnyTimes()
+	gb.memory.EXPECT().Tick(4).AnyTimes()
+	steps := 0
+	gb.cpu.EXPECT().Step().DoAndReturn(func() (int, error) {
+		steps++
+		gb.ticker(4)
+		return 4, nil
+	}).AnyTimes()
+
+	assert.NoError(t, gb.Update())
+	assert.Equal(t, 144*456/4, steps)
+	assert.Equal(t, byte(144), gb.lcd.Read(0xFF44))
+
+	steps = 0
+	assert.NoError(t, gb.Update())
+	assert.Equal(t, ppu.DotsPerFrame/4, steps, "Frames stay in phase with VBlank")
+	assert.Equal(t, byte(144), gb.lcd.Read(0xFF44))
+
+	gb.lcd.Write(0xFF40, 0x00)
+	steps = 0
+	assert.NoError(t, gb.Update())
+	assert.Equal(t, ppu.DotsPerFrame/4, steps, "A frame's worth of cycles runs while the LCD is off")
+}
+
+func TestRumbleHandlerCarriesOverToLoadedCarts(t *testing.T) {
+	gb, memory, _, dir := newSaveTest(t)
+	defer os.RemoveAll(dir)
+	romfile := writeROM(t, dir, 0x1C, 0x00)
+	events := []bool{}
+	gb.SetRumble(func(on bool) {
+		events = append(events, on)
+	})
+
+	cart := expectLoad(memory)
+	assert.NoError(t, gb.LoadCart(romfile))
+	(*cart).Write(0x4000, 0x08)
+	assert.Equal(t, []bool{true}, events)
+
+	cart = expectLoad(memory)
+	assert.NoError(t, gb.LoadCart(romfile))
+	(*cart).Write(0x4000, 0x08)
+	(*cart).Write(0x4000, 0x00)
+	assert.Equal(t, []bool{true, true, false}, events, "Handler is applied to every cart loaded")
+
+	gb.SetRumble(nil)
+	(*cart).Write(0x4000, 0x08)
+	assert.Equal(t, []bool{true, true, false}, events, "Handler is replaced on the loaded cart")
 }
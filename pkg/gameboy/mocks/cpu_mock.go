@@ -5,34 +5,48 @@
 package gameboy_mock
 
 import (
-	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	cpu "github.com/gorkaio/gboy/pkg/cpu"
 )
 
-// MockCPU is a mock of CPU interface
+// MockCPU is a mock of CPU interface.
 type MockCPU struct {
 	ctrl     *gomock.Controller
 	recorder *MockCPUMockRecorder
 }
 
-// MockCPUMockRecorder is the mock recorder for MockCPU
+// MockCPUMockRecorder is the mock recorder for MockCPU.
 type MockCPUMockRecorder struct {
 	mock *MockCPU
 }
 
-// NewMockCPU creates a new mock instance
+// NewMockCPU creates a new mock instance.
 func NewMockCPU(ctrl *gomock.Controller) *MockCPU {
 	mock := &MockCPU{ctrl: ctrl}
 	mock.recorder = &MockCPUMockRecorder{mock}
 	return mock
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use
+// EXPECT returns an object that allows the caller to indicate expected use.
 func (m *MockCPU) EXPECT() *MockCPUMockRecorder {
 	return m.recorder
 }
 
-// Step mocks base method
+// SetTicker mocks base method.
+func (m *MockCPU) SetTicker(arg0 cpu.Ticker) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTicker", arg0)
+}
+
+// SetTicker indicates an expected call of SetTicker.
+func (mr *MockCPUMockRecorder) SetTicker(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTicker", reflect.TypeOf((*MockCPU)(nil).SetTicker), arg0)
+}
+
+// Step mocks base method.
 func (m *MockCPU) Step() (int, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Step")
@@ -41,7 +55,7 @@ func (m *MockCPU) Step() (int, error) {
 	return ret0, ret1
 }
 
-// Step indicates an expected call of Step
+// Step indicates an expected call of Step.
 func (mr *MockCPUMockRecorder) Step() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Step", reflect.TypeOf((*MockCPU)(nil).Step))
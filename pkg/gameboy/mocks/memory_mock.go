@@ -5,59 +5,108 @@
 package gameboy_mock
 
 import (
+	reflect "reflect"
+
 	gomock "github.com/golang/mock/gomock"
+	interrupts "github.com/gorkaio/gboy/pkg/interrupts"
 	memory "github.com/gorkaio/gboy/pkg/memory"
-	reflect "reflect"
 )
 
-// MockMemory is a mock of Memory interface
+// MockMemory is a mock of Memory interface.
 type MockMemory struct {
 	ctrl     *gomock.Controller
 	recorder *MockMemoryMockRecorder
 }
 
-// MockMemoryMockRecorder is the mock recorder for MockMemory
+// MockMemoryMockRecorder is the mock recorder for MockMemory.
 type MockMemoryMockRecorder struct {
 	mock *MockMemory
 }
 
-// NewMockMemory creates a new mock instance
+// NewMockMemory creates a new mock instance.
 func NewMockMemory(ctrl *gomock.Controller) *MockMemory {
 	mock := &MockMemory{ctrl: ctrl}
 	mock.recorder = &MockMemoryMockRecorder{mock}
 	return mock
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use
+// EXPECT returns an object that allows the caller to indicate expected use.
 func (m *MockMemory) EXPECT() *MockMemoryMockRecorder {
 	return m.recorder
 }
 
-// Eject mocks base method
+// Eject mocks base method.
 func (m *MockMemory) Eject() {
 	m.ctrl.T.Helper()
 	m.ctrl.Call(m, "Eject")
 }
 
-// Eject indicates an expected call of Eject
+// Eject indicates an expected call of Eject.
 func (mr *MockMemoryMockRecorder) Eject() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Eject", reflect.TypeOf((*MockMemory)(nil).Eject))
 }
 
-// Load mocks base method
+// Interrupts mocks base method.
+func (m *MockMemory) Interrupts() *interrupts.Controller {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Interrupts")
+	ret0, _ := ret[0].(*interrupts.Controller)
+	return ret0
+}
+
+// Interrupts indicates an expected call of Interrupts.
+func (mr *MockMemoryMockRecorder) Interrupts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Interrupts", reflect.TypeOf((*MockMemory)(nil).Interrupts))
+}
+
+// Load mocks base method.
 func (m *MockMemory) Load(arg0 memory.Cart) {
 	m.ctrl.T.Helper()
 	m.ctrl.Call(m, "Load", arg0)
 }
 
-// Load indicates an expected call of Load
+// Load indicates an expected call of Load.
 func (mr *MockMemoryMockRecorder) Load(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Load", reflect.TypeOf((*MockMemory)(nil).Load), arg0)
 }
 
-// Read mocks base method
+// Map mocks base method.
+func (m *MockMemory) Map(arg0 memory.Device, arg1, arg2 uint16, arg3 ...byte) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Map", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Map indicates an expected call of Map.
+func (mr *MockMemoryMockRecorder) Map(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Map", reflect.TypeOf((*MockMemory)(nil).Map), varargs...)
+}
+
+// RAMBank mocks base method.
+func (m *MockMemory) RAMBank() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RAMBank")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// RAMBank indicates an expected call of RAMBank.
+func (mr *MockMemoryMockRecorder) RAMBank() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RAMBank", reflect.TypeOf((*MockMemory)(nil).RAMBank))
+}
+
+// Read mocks base method.
 func (m *MockMemory) Read(arg0 uint16) byte {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Read", arg0)
@@ -65,19 +114,45 @@ func (m *MockMemory) Read(arg0 uint16) byte {
 	return ret0
 }
 
-// Read indicates an expected call of Read
+// Read indicates an expected call of Read.
 func (mr *MockMemoryMockRecorder) Read(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockMemory)(nil).Read), arg0)
 }
 
-// Write mocks base method
+// ReadVideo mocks base method.
+func (m *MockMemory) ReadVideo(arg0 uint16) byte {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadVideo", arg0)
+	ret0, _ := ret[0].(byte)
+	return ret0
+}
+
+// ReadVideo indicates an expected call of ReadVideo.
+func (mr *MockMemoryMockRecorder) ReadVideo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadVideo", reflect.TypeOf((*MockMemory)(nil).ReadVideo), arg0)
+}
+
+// Tick mocks base method.
+func (m *MockMemory) Tick(arg0 int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Tick", arg0)
+}
+
+// Tick indicates an expected call of Tick.
+func (mr *MockMemoryMockRecorder) Tick(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Tick", reflect.TypeOf((*MockMemory)(nil).Tick), arg0)
+}
+
+// Write mocks base method.
 func (m *MockMemory) Write(arg0 uint16, arg1 byte) {
 	m.ctrl.T.Helper()
 	m.ctrl.Call(m, "Write", arg0, arg1)
 }
 
-// Write indicates an expected call of Write
+// Write indicates an expected call of Write.
 func (mr *MockMemoryMockRecorder) Write(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*MockMemory)(nil).Write), arg0, arg1)
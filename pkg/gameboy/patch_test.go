@@ -0,0 +1,52 @@
+package gameboy_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ipsPatch(address uint16, value byte) []byte {
+	ips := append([]byte("PATCH"), 0x00, byte(address>>8), byte(address), 0x00, 0x01, value)
+	return append(ips, []byte("EOF")...)
+}
+
+func TestLoadCartAppliesPatchNamedLikeTheROM(t *testing.T) {
+	gb, memory, _, dir := newSaveTest(t)
+	defer os.RemoveAll(dir)
+	romfile := writeROM(t, dir, 0x00, 0x00)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "game.ips"), ipsPatch(0x200, 0x42), 0644))
+
+	cart := expectLoad(memory)
+	assert.NoError(t, gb.LoadCart(romfile))
+	assert.Equal(t, byte(0x42), (*cart).Read(0x200))
+
+	rom, err := ioutil.ReadFile(romfile)
+	assert.NoError(t, err)
+	assert.Equal(t, byte(0x00), rom[0x200], "The ROM file is not modified")
+}
+
+func TestLoadCartAppliesGivenPatch(t *testing.T) {
+	gb, memory, _, dir := newSaveTest(t)
+	defer os.RemoveAll(dir)
+	romfile := writeROM(t, dir, 0x00, 0x00)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "game.ips"), ipsPatch(0x200, 0x42), 0644))
+	patchfile := filepath.Join(dir, "hack.ips")
+	assert.NoError(t, ioutil.WriteFile(patchfile, ipsPatch(0x200, 0x24), 0644))
+
+	cart := expectLoad(memory)
+	assert.NoError(t, gb.LoadCart(romfile, patchfile))
+	assert.Equal(t, byte(0x24), (*cart).Read(0x200))
+}
+
+func TestLoadCartFailsOnBrokenPatch(t *testing.T) {
+	gb, _, _, dir := newSaveTest(t)
+	defer os.RemoveAll(dir)
+	romfile := writeROM(t, dir, 0x00, 0x00)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "game.ups"), []byte("UPS1 broken patch"), 0644))
+
+	assert.Error(t, gb.LoadCart(romfile))
+}
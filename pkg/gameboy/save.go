@@ -0,0 +1,71 @@
+package gameboy
+
+import (
+	"bytes"
+	"github.com/gorkaio/gboy/pkg/cart"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultSaveInterval is how often battery-backed RAM is flushed while running
+const DefaultSaveInterval = 5 * time.Second
+
+// SetSaveInterval sets how much emulated time passes between battery-backed RAM
+// flushes. Zero disables periodic flushes, leaving only the one on eject.
+func (gb *Gameboy) SetSaveInterval(interval time.Duration) {
+	gb.saveInterval = interval
+}
+
+// Flush writes battery-backed cart RAM to the .sav file if it changed since the last write
+func (gb *Gameboy) Flush() error {
+	gb.sinceSave = 0
+	if gb.cart == nil || !gb.cart.Type().Battery || gb.savefile == "" {
+		return nil
+	}
+	data := gb.cart.SaveData()
+	if bytes.Equal(data, gb.saved) {
+		return nil
+	}
+	err := cart.WriteFile(gb.savefile, data)
+	if err != nil {
+		return err
+	}
+	gb.saved = data
+	return nil
+}
+
+// loadSave restores battery-backed cart RAM from the .sav file, if there is one,
+// and returns the RAM contents the file now holds
+func loadSave(c *cart.Cart, savefile string) ([]byte, error) {
+	if savefile == "" {
+		return nil, nil
+	}
+	data, err := ioutil.ReadFile(savefile)
+	if os.IsNotExist(err) {
+		return c.SaveData(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = c.LoadSaveData(data)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// savePath returns the .sav file next to the ROM
+func savePath(romfile string) string {
+	return romBase(romfile) + ".sav"
+}
+
+// romBase strips the extension from a ROM path, along with any .gz extension
+func romBase(romfile string) string {
+	if strings.EqualFold(filepath.Ext(romfile), ".gz") {
+		romfile = romfile[:len(romfile)-len(".gz")]
+	}
+	return strings.TrimSuffix(romfile, filepath.Ext(romfile))
+}
@@ -0,0 +1,260 @@
+package gameboy_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	gbcart "github.com/gorkaio/gboy/pkg/cart"
+	"github.com/gorkaio/gboy/pkg/gameboy"
+	mocks "github.com/gorkaio/gboy/pkg/gameboy/mocks"
+	gbmemory "github.com/gorkaio/gboy/pkg/memory"
+	"github.com/gorkaio/gboy/pkg/ppu"
+	"github.com/stretchr/testify/assert"
+)
+
+// writeROM writes a two bank ROM image of the given cart type and returns its path
+func writeROM(t *testing.T, dir string, cartType, ramSize byte) string {
+	data := make([]byte, 0x8000)
+	data[0x147] = cartType
+	data[0x149] = ramSize
+	romfile := filepath.Join(dir, "game.gb")
+	assert.NoError(t, ioutil.WriteFile(romfile, data, 0644))
+	return romfile
+}
+
+func newSaveTest(t *testing.T) (*gameboy.Gameboy, *mocks.MockMemory, *mocks.MockCPU, string) {
+	gb := newTestGameboy(t)
+	dir, err := ioutil.TempDir("", "gboy")
+	assert.NoError(t, err)
+	return gb.Gameboy, gb.memory, gb.cpu, dir
+}
+
+func expectLoad(memory *mocks.MockMemory) *gbmemory.Cart {
+	var cart gbmemory.Cart
+	memory.EXPECT().Load(gomock.Any()).Do(func(c gbmemory.Cart) {
+		cart = c
+	})
+	return &cart
+}
+
+func TestLoadCartRestoresSaveFile(t *testing.T) {
+	gb, memory, _, dir := newSaveTest(t)
+	defer os.RemoveAll(dir)
+	romfile := writeROM(t, dir, 0x03, 0x02)
+	save := make([]byte, 0x2000)
+	save[0x10] = 0x77
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "game.sav"), save, 0644))
+
+	cart := expectLoad(memory)
+	assert.NoError(t, gb.LoadCart(romfile))
+	(*cart).Write(0x0000, 0x0A)
+	assert.Equal(t, byte(0x77), (*cart).Read(0xA010))
+}
+
+func TestLoadCartFailsOnCorruptSaveFile(t *testing.T) {
+	gb, _, _, dir := newSaveTest(t)
+	defer os.RemoveAll(dir)
+	romfile := writeROM(t, dir, 0x03, 0x02)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "game.sav"), make([]byte, 10), 0644))
+
+	assert.Error(t, gb.LoadCart(romfile))
+}
+
+func TestEjectFlushesSaveFile(t *testing.T) {
+	gb, memory, _, dir := newSaveTest(t)
+	defer os.RemoveAll(dir)
+	romfile := writeROM(t, dir, 0x09, 0x02)
+
+	cart := expectLoad(memory)
+	assert.NoError(t, gb.LoadCart(romfile))
+	(*cart).Write(0xA123, 0x42)
+
+	memory.EXPECT().Eject()
+	assert.NoError(t, gb.Eject())
+	save, err := ioutil.ReadFile(filepath.Join(dir, "game.sav"))
+	assert.NoError(t, err)
+	assert.Len(t, save, 0x2000)
+	assert.Equal(t, byte(0x42), save[0x123])
+
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 2, "No temporary files are left behind")
+}
+
+func TestCartsWithoutBatteryAreNotSaved(t *testing.T) {
+	gb, memory, _, dir := newSaveTest(t)
+	defer os.RemoveAll(dir)
+	romfile := writeROM(t, dir, 0x08, 0x02)
+
+	cart := expectLoad(memory)
+	assert.NoError(t, gb.LoadCart(romfile))
+	(*cart).Write(0xA000, 0x42)
+
+	memory.EXPECT().Eject()
+	assert.NoError(t, gb.Eject())
+	_, err := os.Stat(filepath.Join(dir, "game.sav"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestUnchangedRAMIsNotSaved(t *testing.T) {
+	gb, memory, _, dir := newSaveTest(t)
+	defer os.RemoveAll(dir)
+	romfile := writeROM(t, dir, 0x03, 0x02)
+
+	expectLoad(memory)
+	assert.NoError(t, gb.LoadCart(romfile))
+
+	memory.EXPECT().Eject()
+	assert.NoError(t, gb.Eject())
+	_, err := os.Stat(filepath.Join(dir, "game.sav"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestSaveFileIsFlushedPeriodically(t *testing.T) {
+	gb, memory, c, dir := newSaveTest(t)
+	defer os.RemoveAll(dir)
+	romfile := writeROM(t, dir, 0x1B, 0x02)
+	savefile := filepath.Join(dir, "game.sav")
+	gb.SetSaveInterval(30 * time.Millisecond)
+
+	cart := expectLoad(memory)
+	assert.NoError(t, gb.LoadCart(romfile))
+	(*cart).Write(0x0000, 0x0A)
+	(*cart).Write(0xA000, 0x01)
+
+	c.EXPECT().Step().Return(ppu.DotsPerFrame, nil).Times(2)
+	assert.NoError(t, gb.Update())
+	_, err := os.Stat(savefile)
+	assert.True(t, os.IsNotExist(err), "A frame is shorter than the save interval")
+
+	assert.NoError(t, gb.Update())
+	save, err := ioutil.ReadFile(savefile)
+	assert.NoError(t, err)
+	assert.Equal(t, byte(0x01), save[0])
+}
+
+func TestGzippedROMsSaveNextToTheArchive(t *testing.T) {
+	gb, memory, _, dir := newSaveTest(t)
+	defer os.RemoveAll(dir)
+	data := make([]byte, 0x8000)
+	data[0x147] = 0x03
+	data[0x149] = 0x02
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write(data)
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+	romfile := filepath.Join(dir, "game.gb.gz")
+	assert.NoError(t, ioutil.WriteFile(romfile, buf.Bytes(), 0644))
+
+	cart := expectLoad(memory)
+	assert.NoError(t, gb.LoadCart(romfile))
+	(*cart).Write(0x0000, 0x0A)
+	(*cart).Write(0xA000, 0x42)
+
+	memory.EXPECT().Eject()
+	assert.NoError(t, gb.Eject())
+	save, err := ioutil.ReadFile(filepath.Join(dir, "game.sav"))
+	assert.NoError(t, err)
+	assert.Equal(t, byte(0x42), save[0])
+}
+
+func TestLoadWithoutSaveFileKeepsRAMInMemory(t *testing.T) {
+	gb, memory, _, dir := newSaveTest(t)
+	defer os.RemoveAll(dir)
+	data := make([]byte, 0x8000)
+	data[0x147] = 0x03
+	data[0x149] = 0x02
+
+	cart := expectLoad(memory)
+	assert.NoError(t, gb.Load(gbcart.FromReader(bytes.NewReader(data)), ""))
+	(*cart).Write(0x0000, 0x0A)
+	(*cart).Write(0xA000, 0x42)
+
+	memory.EXPECT().Eject()
+	assert.NoError(t, gb.Eject())
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestLoadingAnotherCartFlushesTheCurrentOne(t *testing.T) {
+	gb, memory, _, dir := newSaveTest(t)
+	defer os.RemoveAll(dir)
+	romfile := writeROM(t, dir, 0x03, 0x02)
+
+	cart := expectLoad(memory)
+	assert.NoError(t, gb.LoadCart(romfile))
+	(*cart).Write(0x0000, 0x0A)
+	(*cart).Write(0xA000, 0x42)
+
+	expectLoad(memory)
+	assert.NoError(t, gb.Load(gbcart.FromReader(bytes.NewReader(make([]byte, 0x8000))), ""))
+	save, err := ioutil.ReadFile(filepath.Join(dir, "game.sav"))
+	assert.NoError(t, err)
+	assert.Equal(t, byte(0x42), save[0])
+}
+
+func TestFailedLoadKeepsTheCurrentCart(t *testing.T) {
+	gb, memory, _, dir := newSaveTest(t)
+	defer os.RemoveAll(dir)
+	romfile := writeROM(t, dir, 0x03, 0x02)
+	cart := expectLoad(memory)
+	assert.NoError(t, gb.LoadCart(romfile))
+	cheats := gb.Cheats()
+
+	other, err := ioutil.TempDir("", "gboy")
+	assert.NoError(t, err)
+	defer os.RemoveAll(other)
+	otherfile := writeROM(t, other, 0x03, 0x02)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(other, "game.sav"), make([]byte, 10), 0644))
+	assert.Error(t, gb.LoadCart(otherfile))
+	assert.Same(t, cheats, gb.Cheats())
+
+	(*cart).Write(0x0000, 0x0A)
+	(*cart).Write(0xA000, 0x42)
+	memory.EXPECT().Eject()
+	assert.NoError(t, gb.Eject())
+	save, err := ioutil.ReadFile(filepath.Join(dir, "game.sav"))
+	assert.NoError(t, err)
+	assert.Equal(t, byte(0x42), save[0], "The current cart is still saved to its own file")
+}
+
+func TestSaveFileMode(t *testing.T) {
+	tests := []struct {
+		existing os.FileMode
+		expected os.FileMode
+	}{
+		{0, 0644},
+		{0600, 0600},
+		{0664, 0664},
+	}
+
+	for _, test := range tests {
+		gb, memory, _, dir := newSaveTest(t)
+		romfile := writeROM(t, dir, 0x03, 0x02)
+		savefile := filepath.Join(dir, "game.sav")
+		if test.existing != 0 {
+			assert.NoError(t, ioutil.WriteFile(savefile, make([]byte, 0x2000), test.existing))
+			assert.NoError(t, os.Chmod(savefile, test.existing))
+		}
+
+		cart := expectLoad(memory)
+		assert.NoError(t, gb.LoadCart(romfile))
+		(*cart).Write(0x0000, 0x0A)
+		(*cart).Write(0xA000, 0x42)
+		memory.EXPECT().Eject()
+		assert.NoError(t, gb.Eject())
+
+		info, err := os.Stat(savefile)
+		assert.NoError(t, err)
+		assert.Equal(t, test.expected, info.Mode().Perm(), "Existing mode %#o", test.existing)
+		os.RemoveAll(dir)
+	}
+}
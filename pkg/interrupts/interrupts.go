@@ -0,0 +1,69 @@
+package interrupts
+
+// FlagAddr is the address of the interrupt request register (IF)
+const FlagAddr = 0xFF0F
+
+// EnableAddr is the address of the interrupt enable register (IE)
+const EnableAddr = 0xFFFF
+
+const interruptMask = 0x1F
+
+// Interrupt identifies an interrupt source by its bit in IE and IF
+type Interrupt uint8
+
+// Interrupt sources, in priority order
+const (
+	VBlank Interrupt = iota
+	LCDStat
+	Timer
+	Serial
+	Joypad
+)
+
+// Vector returns the address the CPU jumps to when servicing the interrupt
+func (i Interrupt) Vector() uint16 {
+	return 0x40 + uint16(i)*8
+}
+
+// Controller holds the interrupt enable and request registers
+type Controller struct {
+	enable byte
+	flag   byte
+}
+
+// New creates a new interrupt controller
+func New() *Controller {
+	return &Controller{}
+}
+
+// Request flags an interrupt as requested in IF
+func (c *Controller) Request(i Interrupt) {
+	c.flag |= 1 << i
+}
+
+// Acknowledge clears a requested interrupt from IF
+func (c *Controller) Acknowledge(i Interrupt) {
+	c.flag &^= 1 << i
+}
+
+// Pending returns the interrupts both requested and enabled
+func (c *Controller) Pending() byte {
+	return c.enable & c.flag & interruptMask
+}
+
+// Read reads the IF or IE register
+func (c *Controller) Read(address uint16) byte {
+	if address == FlagAddr {
+		return c.flag | ^byte(interruptMask)
+	}
+	return c.enable
+}
+
+// Write writes the IF or IE register
+func (c *Controller) Write(address uint16, data byte) {
+	if address == FlagAddr {
+		c.flag = data & interruptMask
+		return
+	}
+	c.enable = data
+}
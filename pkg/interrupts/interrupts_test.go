@@ -0,0 +1,50 @@
+package interrupts_test
+
+import (
+	"testing"
+
+	"github.com/gorkaio/gboy/pkg/interrupts"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVectorsFollowPriorityOrder(t *testing.T) {
+	assert.Equal(t, uint16(0x40), interrupts.VBlank.Vector())
+	assert.Equal(t, uint16(0x48), interrupts.LCDStat.Vector())
+	assert.Equal(t, uint16(0x50), interrupts.Timer.Vector())
+	assert.Equal(t, uint16(0x58), interrupts.Serial.Vector())
+	assert.Equal(t, uint16(0x60), interrupts.Joypad.Vector())
+}
+
+func TestRequestedInterruptsAreReadFromIF(t *testing.T) {
+	c := interrupts.New()
+	c.Request(interrupts.Timer)
+	c.Request(interrupts.Joypad)
+	assert.Equal(t, byte(0xF4), c.Read(interrupts.FlagAddr))
+
+	c.Acknowledge(interrupts.Timer)
+	assert.Equal(t, byte(0xF0), c.Read(interrupts.FlagAddr))
+}
+
+func TestIFUpperBitsReadAsOne(t *testing.T) {
+	c := interrupts.New()
+	c.Write(interrupts.FlagAddr, 0x00)
+	assert.Equal(t, byte(0xE0), c.Read(interrupts.FlagAddr))
+	c.Write(interrupts.FlagAddr, 0xFF)
+	assert.Equal(t, byte(0xFF), c.Read(interrupts.FlagAddr))
+}
+
+func TestIEIsReadWrite(t *testing.T) {
+	c := interrupts.New()
+	c.Write(interrupts.EnableAddr, 0xA5)
+	assert.Equal(t, byte(0xA5), c.Read(interrupts.EnableAddr))
+}
+
+func TestPendingRequiresRequestAndEnable(t *testing.T) {
+	c := interrupts.New()
+	c.Request(interrupts.VBlank)
+	c.Request(interrupts.Serial)
+	assert.Equal(t, byte(0x00), c.Pending())
+
+	c.Write(interrupts.EnableAddr, 0xE8)
+	assert.Equal(t, byte(0x08), c.Pending())
+}
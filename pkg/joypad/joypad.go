@@ -0,0 +1,82 @@
+package joypad
+
+import "github.com/gorkaio/gboy/pkg/interrupts"
+
+// Addr is the address of the joypad register (P1)
+const Addr = 0xFF00
+
+// Button identifies a joypad button
+type Button uint8
+
+// Buttons, in the order of their P1 input lines: directions first, then
+// action buttons
+const (
+	Right Button = iota
+	Left
+	Up
+	Down
+	A
+	B
+	Select
+	Start
+)
+
+const (
+	selectDirections = 0x10
+	selectButtons    = 0x20
+	selectMask       = selectDirections | selectButtons
+	inputMask        = 0x0F
+)
+
+// Joypad holds the pressed buttons and the input lines selected through P1
+type Joypad struct {
+	interrupts *interrupts.Controller
+	pressed    byte
+	selected   byte
+}
+
+// New creates a joypad with no buttons pressed and no lines selected
+func New(ic *interrupts.Controller) *Joypad {
+	return &Joypad{
+		interrupts: ic,
+		selected:   selectMask,
+	}
+}
+
+// Press presses a button. A joypad interrupt is requested when it pulls a
+// selected input line low.
+func (j *Joypad) Press(b Button) {
+	before := j.inputs()
+	j.pressed |= 1 << b
+	if j.inputs()&^before != 0 {
+		j.interrupts.Request(interrupts.Joypad)
+	}
+}
+
+// Release releases a button
+func (j *Joypad) Release(b Button) {
+	j.pressed &^= 1 << b
+}
+
+// Read reads P1. Selected lines are active low, and input lines read 0
+// while a button on a selected line is pressed.
+func (j *Joypad) Read(address uint16) byte {
+	return j.selected | (^j.inputs() & inputMask)
+}
+
+// Write selects the input lines. Only bits 4 and 5 are writable.
+func (j *Joypad) Write(address uint16, data byte) {
+	j.selected = data & selectMask
+}
+
+// inputs returns the input lines pulled low by pressed buttons
+func (j *Joypad) inputs() byte {
+	var low byte
+	if j.selected&selectDirections == 0 {
+		low |= j.pressed & inputMask
+	}
+	if j.selected&selectButtons == 0 {
+		low |= j.pressed >> 4
+	}
+	return low
+}
@@ -0,0 +1,55 @@
+package joypad_test
+
+import (
+	"testing"
+
+	"github.com/gorkaio/gboy/pkg/interrupts"
+	"github.com/gorkaio/gboy/pkg/joypad"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoButtonsReadAsOne(t *testing.T) {
+	j := joypad.New(interrupts.New())
+	assert.Equal(t, byte(0x3F), j.Read(joypad.Addr))
+
+	j.Write(joypad.Addr, 0x00)
+	assert.Equal(t, byte(0x0F), j.Read(joypad.Addr))
+}
+
+func TestPressedButtonsReadOnSelectedLines(t *testing.T) {
+	tests := []struct {
+		selected byte
+		expected byte
+	}{
+		{0x30, 0x3F},
+		{0x20, 0x27},
+		{0x10, 0x16},
+		{0x00, 0x06},
+	}
+
+	j := joypad.New(interrupts.New())
+	j.Press(joypad.Down)
+	j.Press(joypad.A)
+	j.Press(joypad.Start)
+	for _, test := range tests {
+		j.Write(joypad.Addr, test.selected)
+		assert.Equal(t, test.expected, j.Read(joypad.Addr), "Selected lines %#02x", test.selected)
+	}
+
+	j.Release(joypad.Down)
+	j.Write(joypad.Addr, 0x20)
+	assert.Equal(t, byte(0x2F), j.Read(joypad.Addr))
+}
+
+func TestPressRequestsInterruptOnSelectedLine(t *testing.T) {
+	ic := interrupts.New()
+	ic.Write(interrupts.EnableAddr, 0x10)
+	j := joypad.New(ic)
+
+	j.Write(joypad.Addr, 0x20)
+	j.Press(joypad.A)
+	assert.Equal(t, byte(0x00), ic.Pending(), "Action buttons are not selected")
+
+	j.Press(joypad.Left)
+	assert.Equal(t, byte(0x10), ic.Pending())
+}
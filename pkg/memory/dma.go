@@ -0,0 +1,99 @@
+package memory
+
+// DMAAddr is the address of the OAM DMA register
+const DMAAddr = 0xFF46
+
+const (
+	oamSize = oamAddressHigh - oamAddressLow + 1
+	// dmaDelay is the number of machine cycles from writing DMA to the
+	// first byte being copied
+	dmaDelay = 2
+)
+
+// dma copies a page of memory into OAM, a byte every machine cycle
+type dma struct {
+	mem       *Memory
+	register  byte
+	delay     int
+	active    bool
+	source    uint16
+	bus       int
+	index     int
+	value     byte
+	requested uint16
+}
+
+func (d *dma) Read(address uint16) byte {
+	return d.register
+}
+
+// Write requests a transfer from the page given. A transfer already
+// running goes on until the new one starts.
+func (d *dma) Write(address uint16, data byte) {
+	d.register = data
+	d.requested = uint16(data) << 8
+	d.delay = dmaDelay
+}
+
+// step advances DMA by a machine cycle
+func (d *dma) step() {
+	if d.delay > 0 {
+		d.delay--
+		if d.delay == 0 {
+			d.active = true
+			d.source = d.requested
+			d.bus = busOf(d.source)
+			if d.source >= echoAddressLow {
+				d.bus = externalBus
+			}
+			d.index = 0
+		}
+	}
+	if !d.active {
+		return
+	}
+	if d.index == oamSize {
+		d.active = false
+		return
+	}
+
+	address := d.source + uint16(d.index)
+	if address >= echoAddressLow {
+		// Sources past WRAM read from its mirror
+		address -= echoAddressLow - wramAddressLow
+	}
+	d.value = d.mem.read(address)
+	d.mem.oam[d.index] = d.value
+	d.index++
+}
+
+// Buses the CPU shares with DMA
+const (
+	externalBus = iota
+	videoBus
+	oamBus
+	internalBus
+)
+
+func busOf(address uint16) int {
+	switch {
+	case address >= vramAddressLow && address <= vramAddressHigh:
+		return videoBus
+	case address < oamAddressLow:
+		return externalBus
+	case address < ioAddressLow:
+		return oamBus
+	}
+	return internalBus
+}
+
+// conflict reports whether a CPU access collides with a running transfer.
+// HRAM and the I/O registers are inside the CPU and can always be accessed,
+// OAM never, and the other addresses only while DMA is not using their bus.
+func (d *dma) conflict(address uint16) bool {
+	if !d.active {
+		return false
+	}
+	bus := busOf(address)
+	return bus == oamBus || bus == d.bus
+}
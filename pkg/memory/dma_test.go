@@ -0,0 +1,166 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorkaio/gboy/pkg/memory"
+	mocks "github.com/gorkaio/gboy/pkg/memory/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+// cycles advances memory by the given number of machine cycles
+func cycles(mem *memory.Memory, n int) {
+	for i := 0; i < n; i++ {
+		mem.Tick(4)
+	}
+}
+
+// startDMA fills a WRAM page and starts copying it to OAM
+func startDMA(mem *memory.Memory, page byte) {
+	for i := uint16(0); i < 0xA0; i++ {
+		mem.Write(uint16(page)<<8+i, byte(i)+1)
+	}
+	mem.Write(memory.DMAAddr, page)
+}
+
+func TestDMARegisterReadsBack(t *testing.T) {
+	mem := memory.New()
+	mem.Write(memory.DMAAddr, 0xC1)
+	assert.Equal(t, byte(0xC1), mem.Read(memory.DMAAddr))
+}
+
+func TestDMACopiesAPageToOAM(t *testing.T) {
+	mem := memory.New()
+	startDMA(mem, 0xC1)
+
+	cycles(mem, 2)
+	assert.Equal(t, byte(0x01), mem.ReadVideo(0xFE00))
+	assert.Equal(t, byte(0x00), mem.ReadVideo(0xFE01))
+
+	cycles(mem, 159)
+	for i := uint16(0); i < 0xA0; i++ {
+		assert.Equal(t, byte(i)+1, mem.ReadVideo(0xFE00+i), "OAM %#04x", 0xFE00+i)
+	}
+}
+
+func TestDMAFromPagesPastWRAMReadsItsMirror(t *testing.T) {
+	mem := memory.New()
+	mem.Write(0xDE00, 0xAB)
+	mem.Write(memory.DMAAddr, 0xFE)
+	cycles(mem, 2)
+	assert.Equal(t, byte(0xAB), mem.ReadVideo(0xFE00))
+}
+
+func TestDMABlocksCPUAccessForItsDuration(t *testing.T) {
+	mem := memory.New()
+	mem.Write(0xC000, 0x42)
+	startDMA(mem, 0xD0)
+
+	cycles(mem, 1)
+	assert.Equal(t, byte(0x42), mem.Read(0xC000), "DMA starts a cycle after the write")
+
+	cycles(mem, 1)
+	assert.NotEqual(t, byte(0x42), mem.Read(0xC000))
+	cycles(mem, 159)
+	assert.NotEqual(t, byte(0x42), mem.Read(0xC000))
+
+	cycles(mem, 1)
+	assert.Equal(t, byte(0x42), mem.Read(0xC000))
+}
+
+func TestHRAMAndIOAreAccessibleDuringDMA(t *testing.T) {
+	mem := memory.New()
+	startDMA(mem, 0xC0)
+	cycles(mem, 10)
+
+	mem.Write(0xFF80, 0x12)
+	assert.Equal(t, byte(0x12), mem.Read(0xFF80))
+	mem.Write(0xFFFF, 0x05)
+	assert.Equal(t, byte(0x05), mem.Read(0xFFFF))
+	assert.Equal(t, byte(0xC0), mem.Read(memory.DMAAddr))
+}
+
+func TestOAMIsInaccessibleDuringDMA(t *testing.T) {
+	mem := memory.New()
+	startDMA(mem, 0xC0)
+	cycles(mem, 10)
+
+	assert.Equal(t, byte(0xFF), mem.Read(0xFE00))
+	mem.Write(0xFE50, 0x00)
+	cycles(mem, 152)
+	assert.Equal(t, byte(0x51), mem.Read(0xFE50), "CPU writes to OAM are lost")
+}
+
+func TestDMABusConflicts(t *testing.T) {
+	tests := []struct {
+		name     string
+		page     byte
+		address  uint16
+		conflict bool
+	}{
+		{"WRAM source, WRAM read", 0xC0, 0xD000, true},
+		{"WRAM source, echo read", 0xC0, 0xE000, true},
+		{"WRAM source, cart ROM read", 0xC0, 0x0100, true},
+		{"WRAM source, cart RAM read", 0xC0, 0xA000, true},
+		{"WRAM source, VRAM read", 0xC0, 0x8000, false},
+		{"VRAM source, VRAM read", 0x80, 0x9000, true},
+		{"VRAM source, WRAM read", 0x80, 0xD000, false},
+		{"ROM source, WRAM read", 0x40, 0xD000, true},
+		{"ROM source, VRAM read", 0x40, 0x8000, false},
+	}
+
+	for _, test := range tests {
+		ctrl := gomock.NewController(t)
+		cart := mocks.NewMockCart(ctrl)
+		cart.EXPECT().Read(gomock.Any()).DoAndReturn(func(address uint16) byte {
+			return byte(address)
+		}).AnyTimes()
+		cart.EXPECT().Write(gomock.Any(), gomock.Any()).AnyTimes()
+
+		mem := memory.New()
+		mem.Load(cart)
+		for i := uint16(0); i < 0x100; i++ {
+			mem.Write(0x8000+i, byte(i))
+			mem.Write(0xC000+i, byte(i))
+		}
+		mem.Write(test.address, 0x99)
+		mem.Write(memory.DMAAddr, test.page)
+		// The byte being transferred is 0x10
+		cycles(mem, 18)
+
+		expected := byte(0x99)
+		if test.conflict {
+			expected = 0x10
+		}
+		assert.Equal(t, expected, mem.Read(test.address), test.name)
+
+		mem.Write(test.address, 0x77)
+		cycles(mem, 160)
+		if test.address != 0x0100 && test.address != 0xA000 {
+			expected = 0x77
+			if test.conflict {
+				expected = 0x99
+			}
+			assert.Equal(t, expected, mem.Read(test.address), "%s, write", test.name)
+		}
+		ctrl.Finish()
+	}
+}
+
+func TestWritingDMAAgainRestartsTheTransfer(t *testing.T) {
+	mem := memory.New()
+	mem.Write(0xD000, 0xEE)
+	startDMA(mem, 0xC0)
+	cycles(mem, 50)
+	mem.Write(memory.DMAAddr, 0xD0)
+
+	cycles(mem, 1)
+	assert.Equal(t, byte(0x32), mem.ReadVideo(0xFE31), "The first transfer goes on until the new one starts")
+	assert.Equal(t, byte(0xFF), mem.Read(0xFE00), "OAM stays blocked")
+
+	cycles(mem, 1)
+	assert.Equal(t, byte(0xEE), mem.ReadVideo(0xFE00))
+	cycles(mem, 160)
+	assert.Equal(t, byte(0xEE), mem.Read(0xFE00))
+}
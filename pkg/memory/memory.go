@@ -2,30 +2,122 @@ package memory
 
 //go:generate mockgen -destination=mocks/cart_mock.go -package=memory_mock github.com/gorkaio/gboy/pkg/memory Cart
 
-const cartAddressHigh = 0x7FFF
+import (
+	"fmt"
+
+	"github.com/gorkaio/gboy/pkg/interrupts"
+	"github.com/gorkaio/gboy/pkg/joypad"
+)
+
+// DMG memory map
+const (
+	cartAddressHigh     = 0x7FFF
+	vramAddressLow      = 0x8000
+	vramAddressHigh     = 0x9FFF
+	cartRAMAddressLow   = 0xA000
+	cartRAMAddressHigh  = 0xBFFF
+	wramAddressLow      = 0xC000
+	wramAddressHigh     = 0xDFFF
+	echoAddressLow      = 0xE000
+	echoAddressHigh     = 0xFDFF
+	oamAddressLow       = 0xFE00
+	oamAddressHigh      = 0xFE9F
+	unusableAddressLow  = 0xFEA0
+	unusableAddressHigh = 0xFEFF
+	ioAddressLow        = 0xFF00
+	ioAddressHigh       = 0xFF7F
+	hramAddressLow      = 0xFF80
+	hramAddressHigh     = 0xFFFE
+)
+
+// openBus is the value read from addresses nothing drives
+const openBus = 0xFF
 
 // Cart interface for the cart
 type Cart interface {
 	Read(addr uint16) byte
 	Write(addr uint16, data byte)
+	ROMBankAt(addr uint16) int
+	RAMBank() int
+}
+
+// Device is a hardware component owning memory-mapped I/O registers
+type Device interface {
+	Read(address uint16) byte
+	Write(address uint16, data byte)
+}
+
+// ioRegister routes an I/O register to its device. Registers with no
+// device keep the last value written, so software can still use them.
+type ioRegister struct {
+	device Device
+	mask   byte
+	value  byte
 }
 
 // Memory defines the memory structure
 type Memory struct {
 	cart       Cart
-	system     []byte
+	vram       [vramAddressHigh - vramAddressLow + 1]byte
+	wram       [wramAddressHigh - wramAddressLow + 1]byte
+	oam        [oamAddressHigh - oamAddressLow + 1]byte
+	io         [ioAddressHigh - ioAddressLow + 1]ioRegister
+	hram       [hramAddressHigh - hramAddressLow + 1]byte
 	cartLoaded bool
+	interrupts *interrupts.Controller
+	joypad     *joypad.Joypad
+	dma        *dma
 }
 
 // New creates a new memory
 func New() *Memory {
 	mem := Memory{
-		system:     make([]byte, 0x8000),
 		cartLoaded: false,
+		interrupts: interrupts.New(),
 	}
+	for i := range mem.io {
+		mem.io[i].value = openBus
+	}
+	mem.joypad = joypad.New(mem.interrupts)
+	mem.dma = &dma{mem: &mem}
+	mem.Map(mem.joypad, joypad.Addr, joypad.Addr, 0x3F)
+	mem.Map(mem.interrupts, interrupts.FlagAddr, interrupts.FlagAddr, 0x1F)
+	mem.Map(mem.dma, DMAAddr, DMAAddr)
 	return &mem
 }
 
+// Tick advances OAM DMA by the given number of clock cycles
+func (mem *Memory) Tick(cycles int) {
+	for ; cycles >= 4; cycles -= 4 {
+		mem.dma.step()
+	}
+}
+
+// Map registers a device as the owner of the I/O registers from low to high.
+// An optional read mask per register marks its implemented bits; the rest read as 1.
+func (mem *Memory) Map(device Device, low, high uint16, readMasks ...byte) error {
+	if low < ioAddressLow || high > ioAddressHigh || low > high {
+		return fmt.Errorf("Cannot map device to %#04x-%#04x outside I/O registers", low, high)
+	}
+	if len(readMasks) != 0 && len(readMasks) != int(high-low)+1 {
+		return fmt.Errorf("Expected %d read masks for %#04x-%#04x, got %d", high-low+1, low, high, len(readMasks))
+	}
+	for address := low; address <= high; address++ {
+		if mem.io[address-ioAddressLow].device != nil {
+			return fmt.Errorf("I/O register %#04x is already mapped", address)
+		}
+	}
+
+	for address := low; address <= high; address++ {
+		mask := byte(0xFF)
+		if len(readMasks) != 0 {
+			mask = readMasks[address-low]
+		}
+		mem.io[address-ioAddressLow] = ioRegister{device: device, mask: mask}
+	}
+	return nil
+}
+
 // Eject ejects the current cartdrige
 func (mem *Memory) Eject() {
 	mem.cart = nil
@@ -38,26 +130,130 @@ func (mem *Memory) Load(cart Cart) {
 	mem.cartLoaded = true
 }
 
+// ROMBankAt returns the cart ROM bank mapped at an address, or 0 outside
+// cart ROM
+func (mem *Memory) ROMBankAt(address uint16) int {
+	if !mem.cartLoaded || !addressInCart(address) {
+		return 0
+	}
+	return mem.cart.ROMBankAt(address)
+}
+
+// Interrupts returns the interrupt controller mapped at IF and IE
+func (mem *Memory) Interrupts() *interrupts.Controller {
+	return mem.interrupts
+}
+
+// RAMBank returns the cart RAM bank mapped at 0xA000-0xBFFF
+func (mem *Memory) RAMBank() int {
+	if !mem.cartLoaded {
+		return 0
+	}
+	return mem.cart.RAMBank()
+}
+
+// Joypad returns the joypad mapped at P1
+func (mem *Memory) Joypad() *joypad.Joypad {
+	return mem.joypad
+}
+
+// ReadVideo reads VRAM or OAM from the PPU side, which has its own bus to them
+func (mem *Memory) ReadVideo(address uint16) byte {
+	switch {
+	case address >= vramAddressLow && address <= vramAddressHigh:
+		return mem.vram[address-vramAddressLow]
+	case address >= oamAddressLow && address <= oamAddressHigh:
+		return mem.oam[address-oamAddressLow]
+	}
+	return openBus
+}
+
+// Read reads from the CPU bus. While OAM DMA runs, reads from the bus it
+// is using get the byte being transferred, and OAM reads as 0xFF.
 func (mem *Memory) Read(address uint16) byte {
-	if addressInCart(address) {
+	if mem.dma.conflict(address) {
+		if busOf(address) == oamBus {
+			return openBus
+		}
+		return mem.dma.value
+	}
+	return mem.read(address)
+}
+
+func (mem *Memory) read(address uint16) byte {
+	switch {
+	case addressInCart(address):
 		if mem.cartLoaded {
 			return mem.cart.Read(address)
 		}
-		return 0xFF
+		return openBus
+	case address <= vramAddressHigh:
+		return mem.vram[address-vramAddressLow]
+	case address <= cartRAMAddressHigh:
+		if mem.cartLoaded {
+			return mem.cart.Read(address)
+		}
+		return openBus
+	case address <= wramAddressHigh:
+		return mem.wram[address-wramAddressLow]
+	case address <= echoAddressHigh:
+		return mem.wram[address-echoAddressLow]
+	case address <= oamAddressHigh:
+		return mem.oam[address-oamAddressLow]
+	case address <= unusableAddressHigh:
+		// DMG reads zero from the unusable area
+		return 0x00
+	case address <= ioAddressHigh:
+		register := mem.io[address-ioAddressLow]
+		if register.device == nil {
+			return register.value
+		}
+		return register.device.Read(address) | ^register.mask
+	case address <= hramAddressHigh:
+		return mem.hram[address-hramAddressLow]
 	}
-
-	return mem.system[address&0x7FFF]
+	// Only the interrupt enable register is left at 0xFFFF
+	return mem.interrupts.Read(address)
 }
 
+// Write writes to the CPU bus. While OAM DMA runs, writes to the bus it is
+// using and to OAM are lost.
 func (mem *Memory) Write(address uint16, data byte) {
-	if addressInCart(address) {
+	if mem.dma.conflict(address) {
+		return
+	}
+	switch {
+	case addressInCart(address):
 		if mem.cartLoaded {
 			mem.cart.Write(address, data)
 		}
-		return
+	case address <= vramAddressHigh:
+		mem.vram[address-vramAddressLow] = data
+	case address <= cartRAMAddressHigh:
+		if mem.cartLoaded {
+			mem.cart.Write(address, data)
+		}
+	case address <= wramAddressHigh:
+		mem.wram[address-wramAddressLow] = data
+	case address <= echoAddressHigh:
+		mem.wram[address-echoAddressLow] = data
+	case address <= oamAddressHigh:
+		mem.oam[address-oamAddressLow] = data
+	case address <= unusableAddressHigh:
+		// Writes to the unusable area are ignored
+	case address <= ioAddressHigh:
+		register := &mem.io[address-ioAddressLow]
+		if register.device != nil {
+			register.device.Write(address, data)
+		} else {
+			register.value = data
+		}
+	case address <= hramAddressHigh:
+		mem.hram[address-hramAddressLow] = data
+	default:
+		// Only the interrupt enable register is left at 0xFFFF
+		mem.interrupts.Write(address, data)
 	}
-
-	mem.system[address&0x7FFF] = data
 }
 
 func addressInCart(address uint16) bool {
@@ -2,6 +2,8 @@ package memory_test
 
 import (
 	"github.com/golang/mock/gomock"
+	"github.com/gorkaio/gboy/pkg/interrupts"
+	"github.com/gorkaio/gboy/pkg/joypad"
 	"github.com/gorkaio/gboy/pkg/memory"
 	mocks "github.com/gorkaio/gboy/pkg/memory/mocks"
 	"github.com/stretchr/testify/assert"
@@ -77,3 +79,235 @@ func TestEjectsCart(t *testing.T) {
 	mem.Eject()
 	assert.Equal(t, byte(0xFF), mem.Read(address))
 }
+
+func TestAsksCartForTheROMBankAtAnAddress(t *testing.T) {
+	ctrlCart := gomock.NewController(t)
+	defer ctrlCart.Finish()
+	cart := mocks.NewMockCart(ctrlCart)
+	cart.EXPECT().ROMBankAt(uint16(0x1234)).Return(0x20)
+
+	mem := memory.New()
+	assert.Equal(t, 0, mem.ROMBankAt(0x1234), "No cart loaded")
+
+	mem.Load(cart)
+	assert.Equal(t, 0x20, mem.ROMBankAt(0x1234))
+	assert.Equal(t, 0, mem.ROMBankAt(0xC000), "Outside cart ROM")
+}
+
+func TestAsksCartForTheRAMBank(t *testing.T) {
+	ctrlCart := gomock.NewController(t)
+	defer ctrlCart.Finish()
+	cart := mocks.NewMockCart(ctrlCart)
+	cart.EXPECT().RAMBank().Return(3)
+
+	mem := memory.New()
+	assert.Equal(t, 0, mem.RAMBank(), "No cart loaded")
+
+	mem.Load(cart)
+	assert.Equal(t, 3, mem.RAMBank())
+}
+
+func TestRoutesInterruptRegistersToController(t *testing.T) {
+	mem := memory.New()
+	mem.Interrupts().Request(interrupts.Timer)
+	assert.Equal(t, byte(0xE4), mem.Read(0xFF0F))
+
+	mem.Write(0xFFFF, 0x05)
+	assert.Equal(t, byte(0x04), mem.Interrupts().Pending())
+
+	mem.Write(0xFF0F, 0x00)
+	assert.Equal(t, byte(0x00), mem.Interrupts().Pending())
+}
+
+func TestRoutesJoypadRegisterToJoypad(t *testing.T) {
+	mem := memory.New()
+	mem.Joypad().Press(joypad.Start)
+	assert.Equal(t, byte(0xFF), mem.Read(joypad.Addr))
+
+	mem.Write(joypad.Addr, 0x10)
+	assert.Equal(t, byte(0xD7), mem.Read(joypad.Addr))
+}
+
+func TestInternalRegionsAreReadWrite(t *testing.T) {
+	tests := []struct {
+		region string
+		low    uint16
+		high   uint16
+	}{
+		{"VRAM", 0x8000, 0x9FFF},
+		{"WRAM", 0xC000, 0xDFFF},
+		{"OAM", 0xFE00, 0xFE9F},
+		{"HRAM", 0xFF80, 0xFFFE},
+	}
+
+	for _, test := range tests {
+		mem := memory.New()
+		mem.Write(test.low, 0x12)
+		mem.Write(test.high, 0x34)
+		assert.Equal(t, byte(0x12), mem.Read(test.low), "%s low boundary %#04x", test.region, test.low)
+		assert.Equal(t, byte(0x34), mem.Read(test.high), "%s high boundary %#04x", test.region, test.high)
+	}
+}
+
+func TestRegionsDoNotOverlap(t *testing.T) {
+	tests := []struct {
+		description string
+		address     uint16
+		neighbour   uint16
+	}{
+		{"VRAM end and cart RAM start", 0x9FFF, 0xA000},
+		{"WRAM end and echo start", 0xDFFF, 0xE000},
+		{"echo end and OAM start", 0xFDFF, 0xFE00},
+		{"OAM end and unusable start", 0xFE9F, 0xFEA0},
+		{"I/O end and HRAM start", 0xFF7F, 0xFF80},
+		{"HRAM end and IE", 0xFFFE, 0xFFFF},
+	}
+
+	for _, test := range tests {
+		mem := memory.New()
+		mem.Write(test.neighbour, 0x00)
+		mem.Write(test.address, 0x5A)
+		assert.NotEqual(t, byte(0x5A), mem.Read(test.neighbour), test.description)
+	}
+}
+
+func TestEchoRAMMirrorsWRAM(t *testing.T) {
+	tests := []struct {
+		wram, echo uint16
+	}{
+		{0xC000, 0xE000},
+		{0xC123, 0xE123},
+		{0xDDFF, 0xFDFF},
+	}
+
+	for _, test := range tests {
+		mem := memory.New()
+		mem.Write(test.wram, 0xAB)
+		assert.Equal(t, byte(0xAB), mem.Read(test.echo), "Reading echo %#04x mirrors WRAM %#04x", test.echo, test.wram)
+		mem.Write(test.echo, 0xCD)
+		assert.Equal(t, byte(0xCD), mem.Read(test.wram), "Writing echo %#04x mirrors WRAM %#04x", test.echo, test.wram)
+	}
+
+	mem := memory.New()
+	mem.Write(0xDE00, 0x11)
+	assert.Equal(t, byte(0x00), mem.Read(0xFE00), "Echo RAM ends before mirroring 0xDE00")
+}
+
+func TestReadVideoReadsVRAMAndOAM(t *testing.T) {
+	mem := memory.New()
+	mem.Write(0x8000, 0x12)
+	mem.Write(0x9FFF, 0x34)
+	mem.Write(0xFE00, 0x56)
+	mem.Write(0xFE9F, 0x78)
+	mem.Write(0xC000, 0x9A)
+	assert.Equal(t, byte(0x12), mem.ReadVideo(0x8000))
+	assert.Equal(t, byte(0x34), mem.ReadVideo(0x9FFF))
+	assert.Equal(t, byte(0x56), mem.ReadVideo(0xFE00))
+	assert.Equal(t, byte(0x78), mem.ReadVideo(0xFE9F))
+	assert.Equal(t, byte(0xFF), mem.ReadVideo(0xC000), "The PPU only sees VRAM and OAM")
+}
+
+func TestUnusableAreaReadsZeroAndIgnoresWrites(t *testing.T) {
+	for _, address := range []uint16{0xFEA0, 0xFEC0, 0xFEFF} {
+		mem := memory.New()
+		mem.Write(address, 0xFF)
+		assert.Equal(t, byte(0x00), mem.Read(address), "Unusable address %#04x", address)
+	}
+}
+
+func TestCartRAMIsRoutedToCart(t *testing.T) {
+	for _, address := range []uint16{0xA000, 0xBFFF} {
+		ctrlCart := gomock.NewController(t)
+		cart := mocks.NewMockCart(ctrlCart)
+		cart.EXPECT().Read(address).Return(byte(0x42))
+		cart.EXPECT().Write(address, byte(0x24))
+
+		mem := memory.New()
+		mem.Load(cart)
+		assert.Equal(t, byte(0x42), mem.Read(address))
+		mem.Write(address, 0x24)
+		ctrlCart.Finish()
+	}
+}
+
+func TestOpenBusWithoutCart(t *testing.T) {
+	for _, address := range []uint16{0x0000, 0x7FFF, 0xA000, 0xBFFF} {
+		mem := memory.New()
+		mem.Write(address, 0x00)
+		assert.Equal(t, byte(0xFF), mem.Read(address), "Address %#04x reads open bus without cart", address)
+	}
+}
+
+type fakeDevice struct {
+	registers map[uint16]byte
+}
+
+func newFakeDevice() *fakeDevice {
+	return &fakeDevice{registers: map[uint16]byte{}}
+}
+
+func (d *fakeDevice) Read(address uint16) byte {
+	return d.registers[address]
+}
+
+func (d *fakeDevice) Write(address uint16, data byte) {
+	d.registers[address] = data
+}
+
+func TestUnmappedIORegistersKeepTheirValue(t *testing.T) {
+	for _, address := range []uint16{0xFF03, 0xFF26, 0xFF7F} {
+		mem := memory.New()
+		assert.Equal(t, byte(0xFF), mem.Read(address), "Unmapped I/O register %#04x before a write", address)
+		mem.Write(address, 0x5A)
+		assert.Equal(t, byte(0x5A), mem.Read(address), "Unmapped I/O register %#04x", address)
+	}
+}
+
+func TestMappedDeviceOwnsItsRegisters(t *testing.T) {
+	device := newFakeDevice()
+	mem := memory.New()
+	assert.NoError(t, mem.Map(device, 0xFF04, 0xFF07))
+
+	mem.Write(0xFF04, 0x12)
+	mem.Write(0xFF07, 0x34)
+	assert.Equal(t, byte(0x12), device.registers[0xFF04])
+	assert.Equal(t, byte(0x34), device.registers[0xFF07])
+	assert.Equal(t, byte(0x12), mem.Read(0xFF04))
+	assert.Equal(t, byte(0x34), mem.Read(0xFF07))
+	assert.Equal(t, byte(0xFF), mem.Read(0xFF08), "Registers past the mapped range stay unmapped")
+}
+
+func TestReadMasksSetUnusedBits(t *testing.T) {
+	device := newFakeDevice()
+	mem := memory.New()
+	assert.NoError(t, mem.Map(device, 0xFF05, 0xFF07, 0xFF, 0xFF, 0x07))
+
+	mem.Write(0xFF07, 0x05)
+	assert.Equal(t, byte(0xFD), mem.Read(0xFF07))
+	assert.Equal(t, byte(0x05), device.registers[0xFF07], "Device receives unmasked writes")
+	mem.Write(0xFF05, 0x05)
+	assert.Equal(t, byte(0x05), mem.Read(0xFF05))
+}
+
+func TestMapRejectsInvalidRegistrations(t *testing.T) {
+	tests := []struct {
+		description string
+		low, high   uint16
+		masks       []byte
+	}{
+		{"Below I/O registers", 0xFEFF, 0xFF00, nil},
+		{"Above I/O registers", 0xFF7F, 0xFF80, nil},
+		{"Inverted range", 0xFF10, 0xFF01, nil},
+		{"Wrong mask count", 0xFF10, 0xFF11, []byte{0xFF}},
+		{"Overlapping interrupt flag register", 0xFF0F, 0xFF0F, nil},
+	}
+
+	for _, test := range tests {
+		mem := memory.New()
+		assert.Error(t, mem.Map(newFakeDevice(), test.low, test.high, test.masks...), test.description)
+	}
+
+	mem := memory.New()
+	assert.NoError(t, mem.Map(newFakeDevice(), 0xFF40, 0xFF41))
+	assert.Error(t, mem.Map(newFakeDevice(), 0xFF41, 0xFF42), "Overlapping a mapped device")
+}
@@ -5,34 +5,63 @@
 package memory_mock
 
 import (
-	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
 )
 
-// MockCart is a mock of Cart interface
+// MockCart is a mock of Cart interface.
 type MockCart struct {
 	ctrl     *gomock.Controller
 	recorder *MockCartMockRecorder
 }
 
-// MockCartMockRecorder is the mock recorder for MockCart
+// MockCartMockRecorder is the mock recorder for MockCart.
 type MockCartMockRecorder struct {
 	mock *MockCart
 }
 
-// NewMockCart creates a new mock instance
+// NewMockCart creates a new mock instance.
 func NewMockCart(ctrl *gomock.Controller) *MockCart {
 	mock := &MockCart{ctrl: ctrl}
 	mock.recorder = &MockCartMockRecorder{mock}
 	return mock
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use
+// EXPECT returns an object that allows the caller to indicate expected use.
 func (m *MockCart) EXPECT() *MockCartMockRecorder {
 	return m.recorder
 }
 
-// Read mocks base method
+// RAMBank mocks base method.
+func (m *MockCart) RAMBank() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RAMBank")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// RAMBank indicates an expected call of RAMBank.
+func (mr *MockCartMockRecorder) RAMBank() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RAMBank", reflect.TypeOf((*MockCart)(nil).RAMBank))
+}
+
+// ROMBankAt mocks base method.
+func (m *MockCart) ROMBankAt(arg0 uint16) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ROMBankAt", arg0)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// ROMBankAt indicates an expected call of ROMBankAt.
+func (mr *MockCartMockRecorder) ROMBankAt(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ROMBankAt", reflect.TypeOf((*MockCart)(nil).ROMBankAt), arg0)
+}
+
+// Read mocks base method.
 func (m *MockCart) Read(arg0 uint16) byte {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Read", arg0)
@@ -40,19 +69,19 @@ func (m *MockCart) Read(arg0 uint16) byte {
 	return ret0
 }
 
-// Read indicates an expected call of Read
+// Read indicates an expected call of Read.
 func (mr *MockCartMockRecorder) Read(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Read", reflect.TypeOf((*MockCart)(nil).Read), arg0)
 }
 
-// Write mocks base method
+// Write mocks base method.
 func (m *MockCart) Write(arg0 uint16, arg1 byte) {
 	m.ctrl.T.Helper()
 	m.ctrl.Call(m, "Write", arg0, arg1)
 }
 
-// Write indicates an expected call of Write
+// Write indicates an expected call of Write.
 func (mr *MockCartMockRecorder) Write(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*MockCart)(nil).Write), arg0, arg1)
@@ -1,49 +0,0 @@
-// Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/gorkaio/gboy/pkg/memory (interfaces: Loader)
-
-// Package memory_mock is a generated GoMock package.
-package memory_mock
-
-import (
-	gomock "github.com/golang/mock/gomock"
-	cart "github.com/gorkaio/gboy/pkg/cart"
-	reflect "reflect"
-)
-
-// MockLoader is a mock of Loader interface
-type MockLoader struct {
-	ctrl     *gomock.Controller
-	recorder *MockLoaderMockRecorder
-}
-
-// MockLoaderMockRecorder is the mock recorder for MockLoader
-type MockLoaderMockRecorder struct {
-	mock *MockLoader
-}
-
-// NewMockLoader creates a new mock instance
-func NewMockLoader(ctrl *gomock.Controller) *MockLoader {
-	mock := &MockLoader{ctrl: ctrl}
-	mock.recorder = &MockLoaderMockRecorder{mock}
-	return mock
-}
-
-// EXPECT returns an object that allows the caller to indicate expected use
-func (m *MockLoader) EXPECT() *MockLoaderMockRecorder {
-	return m.recorder
-}
-
-// Load mocks base method
-func (m *MockLoader) Load(arg0 string) (*cart.Cart, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Load", arg0)
-	ret0, _ := ret[0].(*cart.Cart)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// Load indicates an expected call of Load
-func (mr *MockLoaderMockRecorder) Load(arg0 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Load", reflect.TypeOf((*MockLoader)(nil).Load), arg0)
-}
@@ -0,0 +1,101 @@
+package patch
+
+const bpsMagic = "BPS1"
+
+// BPS actions
+const (
+	bpsSourceRead = iota
+	bpsTargetRead
+	bpsSourceCopy
+	bpsTargetCopy
+)
+
+// applyBPS applies a BPS patch: actions copying from the source, the patch or
+// earlier target bytes into the target, followed by the CRC32 footer
+func applyBPS(rom, patch []byte) ([]byte, error) {
+	if len(patch) < len(bpsMagic)+footerSize {
+		return nil, &FormatError{Format: "BPS", Offset: len(patch), Reason: "patch too short"}
+	}
+	if err := verifyInput("BPS", rom, patch); err != nil {
+		return nil, err
+	}
+
+	d := &decoder{format: "BPS", data: patch, offset: len(bpsMagic), end: len(patch) - footerSize}
+	sourceSize, err := d.number()
+	if err != nil {
+		return nil, err
+	}
+	targetSize, err := d.number()
+	if err != nil {
+		return nil, err
+	}
+	metadataSize, err := d.number()
+	if err != nil {
+		return nil, err
+	}
+	if sourceSize != len(rom) {
+		return nil, &FormatError{Format: "BPS", Offset: d.offset, Reason: "source size does not match the ROM"}
+	}
+	d.offset += metadataSize
+
+	target := make([]byte, targetSize)
+	output, sourceOffset, targetOffset := 0, 0, 0
+	for d.offset < d.end {
+		data, err := d.number()
+		if err != nil {
+			return nil, err
+		}
+		action, length := data&3, data>>2+1
+		if output+length > targetSize {
+			return nil, &FormatError{Format: "BPS", Offset: d.offset, Reason: "action past the end of the target"}
+		}
+
+		switch action {
+		case bpsSourceRead:
+			if output+length > len(rom) {
+				return nil, &FormatError{Format: "BPS", Offset: d.offset, Reason: "read past the end of the source"}
+			}
+			copy(target[output:], rom[output:output+length])
+		case bpsTargetRead:
+			if d.offset+length > d.end {
+				return nil, &FormatError{Format: "BPS", Offset: d.offset, Reason: "unexpected end of patch"}
+			}
+			copy(target[output:], patch[d.offset:d.offset+length])
+			d.offset += length
+		case bpsSourceCopy, bpsTargetCopy:
+			relative, err := d.number()
+			if err != nil {
+				return nil, err
+			}
+			if relative&1 != 0 {
+				relative = -(relative >> 1)
+			} else {
+				relative >>= 1
+			}
+			if action == bpsSourceCopy {
+				sourceOffset += relative
+				if sourceOffset < 0 || sourceOffset+length > len(rom) {
+					return nil, &FormatError{Format: "BPS", Offset: d.offset, Reason: "copy outside the source"}
+				}
+				copy(target[output:], rom[sourceOffset:sourceOffset+length])
+				sourceOffset += length
+				break
+			}
+			targetOffset += relative
+			if targetOffset < 0 || targetOffset >= output {
+				return nil, &FormatError{Format: "BPS", Offset: d.offset, Reason: "copy outside the target"}
+			}
+			// Byte by byte, as the copy may overlap the bytes it writes
+			for i := 0; i < length; i++ {
+				target[output+i] = target[targetOffset]
+				targetOffset++
+			}
+		}
+		output += length
+	}
+
+	if err := verifyTarget("BPS", target, patch); err != nil {
+		return nil, err
+	}
+	return target, nil
+}
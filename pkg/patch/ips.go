@@ -0,0 +1,66 @@
+package patch
+
+const ipsMagic = "PATCH"
+const ipsEOF = 0x454F46
+
+// applyIPS applies an IPS patch: records of 24-bit offset and 16-bit size followed
+// by the data, or by a 16-bit run length and value when the size is zero.
+// An optional 24-bit length after the EOF marker truncates the image.
+func applyIPS(rom, patch []byte) ([]byte, error) {
+	target := make([]byte, len(rom))
+	copy(target, rom)
+
+	offset := len(ipsMagic)
+	read := func(n int) ([]byte, error) {
+		if offset+n > len(patch) {
+			return nil, &FormatError{Format: "IPS", Offset: offset, Reason: "unexpected end of patch"}
+		}
+		data := patch[offset : offset+n]
+		offset += n
+		return data, nil
+	}
+
+	for {
+		record, err := read(3)
+		if err != nil {
+			return nil, err
+		}
+		address := int(record[0])<<16 | int(record[1])<<8 | int(record[2])
+		if address == ipsEOF {
+			break
+		}
+
+		size, err := read(2)
+		if err != nil {
+			return nil, err
+		}
+		length := int(size[0])<<8 | int(size[1])
+		var data []byte
+		if length == 0 {
+			rle, err := read(3)
+			if err != nil {
+				return nil, err
+			}
+			length = int(rle[0])<<8 | int(rle[1])
+			data = make([]byte, length)
+			for i := range data {
+				data[i] = rle[2]
+			}
+		} else if data, err = read(length); err != nil {
+			return nil, err
+		}
+
+		if address+length > len(target) {
+			target = append(target, make([]byte, address+length-len(target))...)
+		}
+		copy(target[address:], data)
+	}
+
+	if truncate, err := read(3); err == nil {
+		length := int(truncate[0])<<16 | int(truncate[1])<<8 | int(truncate[2])
+		if length < len(target) {
+			target = target[:length]
+		}
+	}
+	return target, nil
+}
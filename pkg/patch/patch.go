@@ -0,0 +1,149 @@
+// Package patch applies IPS, UPS and BPS soft patches to ROM images
+package patch
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"hash/crc32"
+
+	"github.com/gorkaio/gboy/pkg/cart"
+)
+
+// Extensions lists the patch file extensions, in the order they are looked for next to a ROM
+var Extensions = []string{".ips", ".ups", ".bps"}
+
+// ErrUnknownFormat is returned for patches that are not IPS, UPS or BPS
+var ErrUnknownFormat = errors.New("Unknown patch format")
+
+// FormatError is returned for malformed patches
+type FormatError struct {
+	Format string
+	Offset int
+	Reason string
+}
+
+func (e *FormatError) Error() string {
+	return fmt.Sprintf("Malformed %s patch at %#x: %s", e.Format, e.Offset, e.Reason)
+}
+
+// ChecksumError is returned when a UPS or BPS checksum does not match
+type ChecksumError struct {
+	Format   string
+	Checksum string
+	Expected uint32
+	Actual   uint32
+}
+
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("%s patch %s CRC32 mismatch: expected %#08x, got %#08x", e.Format, e.Checksum, e.Expected, e.Actual)
+}
+
+// Apply returns a patched copy of rom, telling the patch format by its magic number.
+// The rom slice is never modified.
+func Apply(rom, patch []byte) ([]byte, error) {
+	switch {
+	case bytes.HasPrefix(patch, []byte(ipsMagic)):
+		return applyIPS(rom, patch)
+	case bytes.HasPrefix(patch, []byte(upsMagic)):
+		return applyUPS(rom, patch)
+	case bytes.HasPrefix(patch, []byte(bpsMagic)):
+		return applyBPS(rom, patch)
+	}
+	return nil, ErrUnknownFormat
+}
+
+type patchedSource struct {
+	rom     cart.Source
+	patches []cart.Source
+}
+
+// Patched wraps a ROM source so the patches are applied in order as it is read
+func Patched(rom cart.Source, patches ...cart.Source) cart.Source {
+	return &patchedSource{rom: rom, patches: patches}
+}
+
+func (s *patchedSource) ReadROM() ([]byte, error) {
+	data, err := s.rom.ReadROM()
+	if err != nil {
+		return nil, err
+	}
+	for _, source := range s.patches {
+		patch, err := source.ReadROM()
+		if err != nil {
+			return nil, err
+		}
+		data, err = Apply(data, patch)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// UPS and BPS patches end with the source, target and patch CRC32s
+const footerSize = 12
+
+// verifyInput checks the patch and source CRC32s before the patch is applied
+func verifyInput(format string, source, patch []byte) error {
+	footer := patch[len(patch)-footerSize:]
+	err := verifyCRC(format, "patch", patch[:len(patch)-4], readUint32(footer[8:]))
+	if err != nil {
+		return err
+	}
+	return verifyCRC(format, "source", source, readUint32(footer[0:]))
+}
+
+// verifyTarget checks the target CRC32 once the patch is applied
+func verifyTarget(format string, target, patch []byte) error {
+	footer := patch[len(patch)-footerSize:]
+	return verifyCRC(format, "target", target, readUint32(footer[4:]))
+}
+
+func verifyCRC(format, checksum string, data []byte, expected uint32) error {
+	actual := crc32.ChecksumIEEE(data)
+	if actual != expected {
+		return &ChecksumError{Format: format, Checksum: checksum, Expected: expected, Actual: actual}
+	}
+	return nil
+}
+
+func readUint32(data []byte) uint32 {
+	return uint32(data[0]) | uint32(data[1])<<8 | uint32(data[2])<<16 | uint32(data[3])<<24
+}
+
+// decoder reads the variable length integers used by UPS and BPS
+type decoder struct {
+	format string
+	data   []byte
+	offset int
+	end    int
+}
+
+func (d *decoder) byte() (byte, error) {
+	if d.offset >= d.end {
+		return 0, &FormatError{Format: d.format, Offset: d.offset, Reason: "unexpected end of patch"}
+	}
+	b := d.data[d.offset]
+	d.offset++
+	return b, nil
+}
+
+func (d *decoder) number() (int, error) {
+	value, shift := 0, 1
+	for {
+		b, err := d.byte()
+		if err != nil {
+			return 0, err
+		}
+		value += int(b&0x7F) * shift
+		if b&0x80 != 0 {
+			return value, nil
+		}
+		shift <<= 7
+		value += shift
+		if shift > 1<<28 {
+			return 0, &FormatError{Format: d.format, Offset: d.offset, Reason: "number too large"}
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package patch_test
+
+import (
+	"bytes"
+	"hash/crc32"
+	"testing"
+
+	"github.com/gorkaio/gboy/pkg/cart"
+	"github.com/gorkaio/gboy/pkg/patch"
+	"github.com/stretchr/testify/assert"
+)
+
+func testROM() []byte {
+	rom := make([]byte, 0x200)
+	for i := range rom {
+		rom[i] = byte(i)
+	}
+	return rom
+}
+
+func number(value int) []byte {
+	out := []byte{}
+	for {
+		x := byte(value & 0x7F)
+		value >>= 7
+		if value == 0 {
+			return append(out, 0x80|x)
+		}
+		out = append(out, x)
+		value--
+	}
+}
+
+func crc(data []byte) []byte {
+	sum := crc32.ChecksumIEEE(data)
+	return []byte{byte(sum), byte(sum >> 8), byte(sum >> 16), byte(sum >> 24)}
+}
+
+// withFooter appends the source, target and patch CRC32s
+func withFooter(data, source, target []byte) []byte {
+	data = append(data, crc(source)...)
+	data = append(data, crc(target)...)
+	return append(data, crc(data)...)
+}
+
+// upsPatch encodes the difference between source and target as a UPS patch
+func upsPatch(source, target []byte) []byte {
+	data := append([]byte("UPS1"), number(len(source))...)
+	data = append(data, number(len(target))...)
+	xor := func(i int) byte {
+		if i < len(source) {
+			return source[i] ^ target[i]
+		}
+		return target[i]
+	}
+	for offset := 0; offset < len(target); offset++ {
+		skip := 0
+		for ; offset < len(target) && xor(offset) == 0; offset++ {
+			skip++
+		}
+		if offset == len(target) {
+			break
+		}
+		data = append(data, number(skip)...)
+		for ; offset < len(target) && xor(offset) != 0; offset++ {
+			data = append(data, xor(offset))
+		}
+		data = append(data, 0x00)
+	}
+	return withFooter(data, source, target)
+}
+
+func TestAppliesIPSPatches(t *testing.T) {
+	rom := testROM()
+	ips := []byte("PATCH")
+	ips = append(ips, 0x00, 0x00, 0x10, 0x00, 0x02, 0xAA, 0xBB)
+	ips = append(ips, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x04, 0xCC)
+	ips = append(ips, 0x00, 0x01, 0xFF, 0x00, 0x02, 0xDD, 0xEE)
+	ips = append(ips, []byte("EOF")...)
+
+	patched, err := patch.Apply(rom, ips)
+	assert.NoError(t, err)
+	assert.Len(t, patched, 0x201, "Records past the end grow the image")
+	assert.Equal(t, []byte{0xAA, 0xBB, 0x12}, patched[0x10:0x13])
+	assert.Equal(t, []byte{0xCC, 0xCC, 0xCC, 0xCC, 0x04}, patched[0x100:0x105], "Run length encoded record")
+	assert.Equal(t, []byte{0xDD, 0xEE}, patched[0x1FF:])
+	assert.Equal(t, testROM(), rom, "The ROM is not modified")
+}
+
+func TestIPSTruncatesImage(t *testing.T) {
+	ips := append([]byte("PATCHEOF"), 0x00, 0x01, 0x00)
+	patched, err := patch.Apply(testROM(), ips)
+	assert.NoError(t, err)
+	assert.Equal(t, testROM()[:0x100], patched)
+}
+
+func TestRejectsMalformedIPSPatches(t *testing.T) {
+	for _, ips := range [][]byte{
+		[]byte("PATCH"),
+		append([]byte("PATCH"), 0x00, 0x00, 0x10, 0x00, 0x04, 0xAA),
+		append([]byte("PATCH"), 0x00, 0x00, 0x10, 0x00, 0x00, 0x01),
+	} {
+		_, err := patch.Apply(testROM(), ips)
+		assert.IsType(t, &patch.FormatError{}, err)
+	}
+}
+
+func TestAppliesUPSPatches(t *testing.T) {
+	rom := testROM()
+	target := append(testROM(), 0x01, 0x00, 0x02)
+	target[0x00] = 0xFF
+	target[0x80] = 0x00
+	target[0x81] = 0x00
+	target[0x1FF] = 0x42
+
+	patched, err := patch.Apply(rom, upsPatch(rom, target))
+	assert.NoError(t, err)
+	assert.Equal(t, target, patched)
+	assert.Equal(t, testROM(), rom, "The ROM is not modified")
+}
+
+func TestUPSChecksumsAreEnforced(t *testing.T) {
+	rom := testROM()
+	target := testROM()
+	target[0x10] = 0x00
+	ups := upsPatch(rom, target)
+
+	other := testROM()
+	other[0x20] = 0x00
+	_, err := patch.Apply(other, ups)
+	assert.Equal(t, "source", err.(*patch.ChecksumError).Checksum)
+
+	corrupt := append([]byte{}, ups...)
+	corrupt[len(corrupt)-13] ^= 0x01
+	_, err = patch.Apply(rom, corrupt)
+	assert.Equal(t, "patch", err.(*patch.ChecksumError).Checksum)
+
+	wrongTarget := upsPatch(rom, target)[:len(ups)-12]
+	wrongTarget = withFooter(wrongTarget, rom, rom)
+	_, err = patch.Apply(rom, wrongTarget)
+	assert.Equal(t, &patch.ChecksumError{Format: "UPS", Checksum: "target", Expected: crc32.ChecksumIEEE(rom), Actual: crc32.ChecksumIEEE(target)}, err)
+}
+
+func bpsAction(action, length int) []byte {
+	return number((length-1)<<2 | action)
+}
+
+func bpsOffset(relative int) []byte {
+	if relative < 0 {
+		return number(-relative<<1 | 1)
+	}
+	return number(relative << 1)
+}
+
+func TestAppliesBPSPatches(t *testing.T) {
+	rom := testROM()
+	target := make([]byte, 0x30)
+	copy(target[0x00:], rom[0x00:0x10])
+	copy(target[0x10:], []byte{0xAA, 0xBB, 0xCC, 0xDD})
+	copy(target[0x14:], rom[0x100:0x108])
+	copy(target[0x1C:], rom[0x80:0x84])
+	copy(target[0x20:], []byte{0xAA, 0xBB, 0xAA, 0xBB, 0xAA, 0xBB, 0xAA, 0xBB})
+	copy(target[0x28:], rom[0x28:0x30])
+
+	bps := append([]byte("BPS1"), number(len(rom))...)
+	bps = append(bps, number(len(target))...)
+	bps = append(bps, number(4)...)
+	bps = append(bps, []byte("meta")...)
+	bps = append(bps, bpsAction(0, 0x10)...)
+	bps = append(bps, bpsAction(1, 4)...)
+	bps = append(bps, 0xAA, 0xBB, 0xCC, 0xDD)
+	bps = append(bps, bpsAction(2, 8)...)
+	bps = append(bps, bpsOffset(0x100)...)
+	bps = append(bps, bpsAction(2, 4)...)
+	bps = append(bps, bpsOffset(0x80-0x108)...)
+	bps = append(bps, bpsAction(1, 2)...)
+	bps = append(bps, 0xAA, 0xBB)
+	bps = append(bps, bpsAction(3, 6)...)
+	bps = append(bps, bpsOffset(0x20)...)
+	bps = append(bps, bpsAction(0, 8)...)
+	bps = withFooter(bps, rom, target)
+
+	patched, err := patch.Apply(rom, bps)
+	assert.NoError(t, err)
+	assert.Equal(t, target, patched)
+	assert.Equal(t, testROM(), rom, "The ROM is not modified")
+}
+
+func TestBPSChecksumsAreEnforced(t *testing.T) {
+	rom := testROM()
+	bps := append([]byte("BPS1"), number(len(rom))...)
+	bps = append(bps, number(len(rom))...)
+	bps = append(bps, number(0)...)
+	bps = append(bps, bpsAction(0, len(rom))...)
+
+	other := testROM()
+	other[0] = 0xFF
+	_, err := patch.Apply(other, withFooter(bps, rom, rom))
+	assert.Equal(t, "source", err.(*patch.ChecksumError).Checksum)
+
+	_, err = patch.Apply(rom, withFooter(bps, rom, other))
+	assert.Equal(t, "target", err.(*patch.ChecksumError).Checksum)
+
+	corrupt := withFooter(bps, rom, rom)
+	corrupt[len(corrupt)-1] ^= 0xFF
+	_, err = patch.Apply(rom, corrupt)
+	assert.Equal(t, "patch", err.(*patch.ChecksumError).Checksum)
+}
+
+func TestRejectsUnknownPatchFormats(t *testing.T) {
+	_, err := patch.Apply(testROM(), []byte("NOT A PATCH"))
+	assert.Equal(t, patch.ErrUnknownFormat, err)
+}
+
+func TestPatchedSourceAppliesPatchesInOrder(t *testing.T) {
+	rom := testROM()
+	first := append([]byte("PATCH"), 0x00, 0x00, 0x00, 0x00, 0x01, 0x11)
+	first = append(first, []byte("EOF")...)
+	second := append([]byte("PATCH"), 0x00, 0x00, 0x00, 0x00, 0x01, 0x22)
+	second = append(second, []byte("EOF")...)
+
+	source := patch.Patched(cart.FromReader(bytes.NewReader(rom)), cart.FromReader(bytes.NewReader(first)), cart.FromReader(bytes.NewReader(second)))
+	patched, err := source.ReadROM()
+	assert.NoError(t, err)
+	assert.Equal(t, byte(0x22), patched[0])
+}
@@ -0,0 +1,58 @@
+package patch
+
+const upsMagic = "UPS1"
+
+// applyUPS applies a UPS patch: hunks skipping a number of bytes and then
+// XORing the image until a zero byte, followed by the CRC32 footer
+func applyUPS(rom, patch []byte) ([]byte, error) {
+	if len(patch) < len(upsMagic)+footerSize {
+		return nil, &FormatError{Format: "UPS", Offset: len(patch), Reason: "patch too short"}
+	}
+	if err := verifyInput("UPS", rom, patch); err != nil {
+		return nil, err
+	}
+
+	d := &decoder{format: "UPS", data: patch, offset: len(upsMagic), end: len(patch) - footerSize}
+	sourceSize, err := d.number()
+	if err != nil {
+		return nil, err
+	}
+	targetSize, err := d.number()
+	if err != nil {
+		return nil, err
+	}
+	if sourceSize != len(rom) {
+		return nil, &FormatError{Format: "UPS", Offset: d.offset, Reason: "source size does not match the ROM"}
+	}
+
+	target := make([]byte, targetSize)
+	copy(target, rom)
+	offset := 0
+	for d.offset < d.end {
+		skip, err := d.number()
+		if err != nil {
+			return nil, err
+		}
+		offset += skip
+		for {
+			x, err := d.byte()
+			if err != nil {
+				return nil, err
+			}
+			if x == 0 {
+				offset++
+				break
+			}
+			if offset >= targetSize {
+				return nil, &FormatError{Format: "UPS", Offset: d.offset, Reason: "hunk past the end of the target"}
+			}
+			target[offset] ^= x
+			offset++
+		}
+	}
+
+	if err := verifyTarget("UPS", target, patch); err != nil {
+		return nil, err
+	}
+	return target, nil
+}
@@ -0,0 +1,208 @@
+package ppu
+
+// Pixel fetcher timings, in dots
+const (
+	// fetchDots is the time to read the tile number and both bytes of its
+	// data, two dots each. The fetcher pushes to the FIFO on the next dot.
+	fetchDots = 6
+	pushDot   = fetchDots + 1
+	// spriteFetchDots is the time the background fetcher is paused to
+	// fetch a sprite
+	spriteFetchDots = 6
+	// spriteFetchWait is how far the background fetcher must be into a
+	// fetch before a sprite fetch can start
+	spriteFetchWait = 4
+)
+
+// objPixel is a sprite pixel waiting in the object FIFO
+type objPixel struct {
+	color      byte
+	obp1       bool
+	bgPriority bool
+}
+
+// fifo draws lines dot by dot, emulating the DMG background and sprite
+// fetchers and the pixel FIFOs they fill
+type fifo struct {
+	ppu      *PPU
+	bg       [8]byte
+	bgLen    int
+	obj      [8]objPixel
+	fetchDot int
+	fetchX   int
+	x        int
+	discard  int
+	window   bool
+	sprite   int
+	stall    int
+}
+
+func newFIFO(ppu *PPU) *fifo {
+	return &fifo{ppu: ppu}
+}
+
+func (f *fifo) start() {
+	f.bgLen = 0
+	f.obj = [8]objPixel{}
+	// The first fetch of every line is thrown away
+	f.fetchDot = -fetchDots
+	f.fetchX = 0
+	f.x = 0
+	f.discard = int(f.ppu.scx % 8)
+	f.window = false
+	f.sprite = 0
+	f.stall = 0
+}
+
+func (f *fifo) step() bool {
+	if f.stall > 0 {
+		f.stall--
+		return false
+	}
+
+	// Sprites are fetched as the pixel they start at is about to be shifted
+	// out, once the background fetcher is done reading tile data
+	pending := f.pendingSprite()
+	if pending && f.fetchDot >= spriteFetchWait && f.bgLen > 0 {
+		f.fetchSprite()
+		f.stall = spriteFetchDots - 1
+		return false
+	}
+
+	f.fetch()
+	if pending {
+		return false
+	}
+
+	ppu := f.ppu
+	windowX := int(ppu.wx) - 7
+	if !f.window && f.bgLen > 0 && ppu.windowTriggered && ppu.lcdc&lcdcWindowEnable != 0 && f.x >= windowX {
+		// The window restarts the background fetcher, this dot being the
+		// first of its fetch
+		f.window = true
+		f.bgLen = 0
+		f.fetchDot = 1
+		f.fetchX = 0
+		f.discard = 0
+		if windowX < 0 {
+			f.discard = -windowX
+		}
+		return false
+	}
+
+	if f.bgLen == 0 {
+		return false
+	}
+	f.output()
+	if f.x < ScreenWidth {
+		return false
+	}
+
+	if f.window {
+		ppu.windowLine++
+	}
+	return true
+}
+
+// pendingSprite reports whether the next sprite starts at the current pixel
+func (f *fifo) pendingSprite() bool {
+	ppu := f.ppu
+	return f.discard == 0 &&
+		ppu.lcdc&lcdcOBJEnable != 0 &&
+		f.sprite < len(ppu.sprites) &&
+		ppu.sprites[f.sprite].x <= f.x
+}
+
+// fetch advances the background fetcher by a dot
+func (f *fifo) fetch() {
+	if f.fetchDot < pushDot {
+		f.fetchDot++
+	}
+	if f.fetchDot < pushDot || f.bgLen > 0 {
+		return
+	}
+
+	ppu := f.ppu
+	var tileMap uint16
+	var x, y byte
+	if f.window {
+		tileMap = tileMap0
+		if ppu.lcdc&lcdcWindowMap != 0 {
+			tileMap = tileMap1
+		}
+		x = byte(f.fetchX * 8)
+		y = byte(ppu.windowLine)
+	} else {
+		tileMap = tileMap0
+		if ppu.lcdc&lcdcBGTileMap != 0 {
+			tileMap = tileMap1
+		}
+		x = ppu.scx&^7 + byte(f.fetchX*8)
+		y = ppu.ly + ppu.scy
+	}
+	for i := range f.bg {
+		f.bg[i] = ppu.tilePixel(tileMap, x+byte(i), y)
+	}
+	f.bgLen = len(f.bg)
+	f.fetchDot = 0
+	f.fetchX++
+}
+
+// fetchSprite merges the next sprite into the object FIFO. Pixels already
+// there belong to sprites with priority, and only transparent ones are
+// replaced.
+func (f *fifo) fetchSprite() {
+	ppu := f.ppu
+	s := ppu.sprites[f.sprite]
+	f.sprite++
+
+	low := ppu.video.ReadVideo(s.address)
+	high := ppu.video.ReadVideo(s.address + 1)
+	for i := 0; i < 8; i++ {
+		position := s.x + i - f.x
+		if position < 0 || f.obj[position].color != 0 {
+			continue
+		}
+		bit := byte(7 - i)
+		if s.attributes&attrXFlip != 0 {
+			bit = byte(i)
+		}
+		f.obj[position] = objPixel{
+			color:      (high>>bit&1)<<1 | low>>bit&1,
+			obp1:       s.attributes&attrPalette != 0,
+			bgPriority: s.attributes&attrBGPriority != 0,
+		}
+	}
+}
+
+// output shifts a pixel out of the FIFOs, to the screen unless it is
+// discarded because of SCX
+func (f *fifo) output() {
+	ppu := f.ppu
+	bg := f.bg[len(f.bg)-f.bgLen]
+	f.bgLen--
+	if f.discard > 0 {
+		f.discard--
+		return
+	}
+
+	obj := f.obj[0]
+	copy(f.obj[:], f.obj[1:])
+	f.obj[len(f.obj)-1] = objPixel{}
+
+	var shade byte
+	if ppu.lcdc&lcdcBGEnable != 0 {
+		shade = ppu.bgp >> (bg * 2) & 0x03
+	} else {
+		bg = 0
+	}
+	if obj.color != 0 && ppu.lcdc&lcdcOBJEnable != 0 && !(obj.bgPriority && bg != 0) {
+		palette := ppu.obp0
+		if obj.obp1 {
+			palette = ppu.obp1
+		}
+		shade = palette >> (obj.color * 2) & 0x03
+	}
+	ppu.back[ppu.line][f.x] = shade
+	f.x++
+}
@@ -0,0 +1,97 @@
+package ppu_test
+
+import (
+	"testing"
+
+	"github.com/gorkaio/gboy/pkg/ppu"
+	"github.com/stretchr/testify/assert"
+)
+
+// hblankStart returns the dot the first line enters HBlank at
+func hblankStart(p *ppu.PPU) int {
+	dot := 0
+	for p.Mode() != ppu.Drawing {
+		p.Tick(1)
+		dot++
+	}
+	for p.Mode() == ppu.Drawing {
+		p.Tick(1)
+		dot++
+	}
+	return dot
+}
+
+func TestFIFOModeThreeLength(t *testing.T) {
+	tests := []struct {
+		name    string
+		scx     byte
+		wx      byte
+		sprites []int
+		dot     int
+	}{
+		{"Shortest", 0, 0xFF, nil, 252},
+		{"SCX fine scroll", 3, 0xFF, nil, 255},
+		{"SCX fine scroll", 7, 0xFF, nil, 259},
+		{"SCX coarse scroll", 8, 0xFF, nil, 252},
+		{"Window", 0, 87, nil, 258},
+		{"Window at the left edge", 0, 7, nil, 258},
+		{"Sprite aligned to a tile", 0, 0xFF, []int{8}, 263},
+		{"Sprite at X 0", 0, 0xFF, []int{0}, 263},
+		{"Sprite off tile", 0, 0xFF, []int{13}, 258},
+		{"Sprite off tile", 0, 0xFF, []int{10}, 261},
+		{"Sprite with SCX", 3, 0xFF, []int{8}, 263},
+		{"Sprite off screen", 0, 0xFF, []int{168}, 252},
+		{"Sprites sharing a tile", 0, 0xFF, []int{8, 8}, 269},
+		{"Ten sprites", 0, 0xFF, []int{8, 8, 8, 8, 8, 8, 8, 8, 8, 8}, 317},
+	}
+
+	for _, test := range tests {
+		p, video := newSpriteTest(ppu.FIFORenderer)
+		p.Write(ppu.LCDCAddr, 0xB3)
+		p.Write(ppu.SCXAddr, test.scx)
+		p.Write(ppu.WXAddr, test.wx)
+		for i, x := range test.sprites {
+			setSprite(video, i, x-8, 0, 0, 0x00)
+		}
+
+		assert.Equal(t, test.dot, hblankStart(p), "%s, SCX %d WX %d sprites %v", test.name, test.scx, test.wx, test.sprites)
+	}
+}
+
+func TestScanlineModeThreeLengthIsFixed(t *testing.T) {
+	p, video := newSpriteTest(ppu.ScanlineRenderer)
+	p.Write(ppu.SCXAddr, 7)
+	setSprite(video, 0, 0, 0, 0, 0x00)
+	assert.Equal(t, 252, hblankStart(p))
+}
+
+func TestFIFOShowsMidLineChanges(t *testing.T) {
+	p, video := newRenderTest(ppu.FIFORenderer)
+	solidTile(video, 0x8010, 1)
+	for address := uint16(0x9800); address < 0x9800+32; address++ {
+		video[address] = 1
+	}
+
+	// Pixel 0 is shifted out 13 dots into mode 3, then one per dot
+	tickTo(p, 0, 80+13+40)
+	p.Write(ppu.BGPAddr, 0xE8)
+	p.Tick(40)
+	p.Write(ppu.LCDCAddr, 0x90)
+	p.Tick(456*144 - 80 - 13 - 80)
+
+	frame := p.Frame()
+	assert.Equal(t, byte(1), frame[0][40])
+	assert.Equal(t, byte(2), frame[0][41], "BGP changed")
+	assert.Equal(t, byte(2), frame[0][80])
+	assert.Equal(t, byte(0), frame[0][81], "Background disabled")
+	assert.Equal(t, byte(0), frame[1][0])
+}
+
+func TestFIFOSTATTimingFollowsModeThreeLength(t *testing.T) {
+	p, video := newSpriteTest(ppu.FIFORenderer)
+	setSprite(video, 0, 0, 0, 0, 0x00)
+	tickTo(p, 0, 252)
+	assert.Equal(t, byte(0x83), p.Read(ppu.STATAddr)&0x83)
+	p.Tick(11)
+	assert.Equal(t, byte(0x80), p.Read(ppu.STATAddr)&0x83)
+}
@@ -0,0 +1,315 @@
+package ppu
+
+import (
+	"github.com/gorkaio/gboy/pkg/interrupts"
+)
+
+// LCD registers mapped in I/O memory
+const (
+	LCDCAddr = 0xFF40
+	STATAddr = 0xFF41
+	SCYAddr  = 0xFF42
+	SCXAddr  = 0xFF43
+	LYAddr   = 0xFF44
+	LYCAddr  = 0xFF45
+	BGPAddr  = 0xFF47
+	OBP0Addr = 0xFF48
+	OBP1Addr = 0xFF49
+	WYAddr   = 0xFF4A
+	WXAddr   = 0xFF4B
+)
+
+// Frame timings, in dots (one dot per CPU clock)
+const (
+	dotsPerLine   = 456
+	oamScanDots   = 80
+	drawingDots   = 172
+	visibleLines  = 144
+	linesPerFrame = 154
+)
+
+// DotsPerFrame is the number of dots the PPU takes to draw a frame
+const DotsPerFrame = dotsPerLine * linesPerFrame
+
+// LCDC bits
+const (
+	lcdcBGEnable     = 0x01
+	lcdcOBJEnable    = 0x02
+	lcdcOBJSize      = 0x04
+	lcdcBGTileMap    = 0x08
+	lcdcTileData     = 0x10
+	lcdcWindowEnable = 0x20
+	lcdcWindowMap    = 0x40
+	lcdcEnable       = 0x80
+)
+
+// STAT bits
+const (
+	statCoincidence = 0x04
+	statHBlankIRQ   = 0x08
+	statVBlankIRQ   = 0x10
+	statOAMIRQ      = 0x20
+	statLYCIRQ      = 0x40
+	statWritable    = statHBlankIRQ | statVBlankIRQ | statOAMIRQ | statLYCIRQ
+)
+
+// Mode is the PPU mode reported in the low bits of STAT
+type Mode byte
+
+// PPU modes
+const (
+	HBlank Mode = iota
+	VBlank
+	OAMScan
+	Drawing
+)
+
+// Renderer selects how the PPU draws the screen
+type Renderer int
+
+const (
+	// ScanlineRenderer draws each line at once, with a fixed mode 3 length.
+	// It is the fastest.
+	ScanlineRenderer Renderer = iota
+	// FIFORenderer emulates the pixel fetchers dot by dot, so changes in
+	// the middle of a line show and mode 3 length varies as on hardware
+	FIFORenderer
+)
+
+// renderer draws a line during mode 3
+type renderer interface {
+	start()
+	// step advances drawing by a dot, and reports whether the line is done
+	step() bool
+}
+
+// Interrupts is where the PPU requests VBlank and STAT interrupts
+type Interrupts interface {
+	Request(i interrupts.Interrupt)
+}
+
+// VideoMemory gives the PPU access to VRAM and OAM through its own bus
+type VideoMemory interface {
+	ReadVideo(address uint16) byte
+}
+
+// PPU is the picture processing unit
+type PPU struct {
+	irq             Interrupts
+	video           VideoMemory
+	lcdc            byte
+	stat            byte
+	scy             byte
+	scx             byte
+	ly              byte
+	lyc             byte
+	bgp             byte
+	obp0            byte
+	obp1            byte
+	wy              byte
+	wx              byte
+	line            int
+	dot             int
+	mode            Mode
+	coincidence     bool
+	statLine        bool
+	windowTriggered bool
+	windowLine      int
+	sprites         []sprite
+	renderer        renderer
+	front           *Frame
+	back            *Frame
+}
+
+// New creates a PPU in the state the boot ROM leaves it, with the LCD on
+func New(irq Interrupts, video VideoMemory, renderer Renderer) *PPU {
+	ppu := &PPU{
+		irq:     irq,
+		video:   video,
+		lcdc:    0x91,
+		bgp:     0xFC,
+		obp0:    0xFF,
+		obp1:    0xFF,
+		mode:    OAMScan,
+		sprites: make([]sprite, 0, maxSpritesPerLine),
+		front:   &Frame{},
+		back:    &Frame{},
+	}
+	if renderer == FIFORenderer {
+		ppu.renderer = newFIFO(ppu)
+	} else {
+		ppu.renderer = &scanline{ppu: ppu}
+	}
+	ppu.updateStat()
+	return ppu
+}
+
+// Frame returns the last frame the PPU completed
+func (ppu *PPU) Frame() *Frame {
+	return ppu.front
+}
+
+// Mode returns the current PPU mode
+func (ppu *PPU) Mode() Mode {
+	return ppu.mode
+}
+
+// Enabled returns whether the LCD is on
+func (ppu *PPU) Enabled() bool {
+	return ppu.lcdc&lcdcEnable != 0
+}
+
+// Tick advances the PPU by the given number of dots
+func (ppu *PPU) Tick(dots int) {
+	if !ppu.Enabled() {
+		return
+	}
+	for ; dots > 0; dots-- {
+		ppu.step()
+	}
+}
+
+func (ppu *PPU) step() {
+	ppu.dot++
+	switch {
+	case ppu.dot == dotsPerLine:
+		ppu.dot = 0
+		ppu.line = (ppu.line + 1) % linesPerFrame
+		ppu.ly = byte(ppu.line)
+		switch {
+		case ppu.line < visibleLines:
+			ppu.mode = OAMScan
+		case ppu.line == visibleLines:
+			ppu.mode = VBlank
+			ppu.irq.Request(interrupts.VBlank)
+			ppu.endFrame()
+		}
+	case ppu.line == linesPerFrame-1 && ppu.dot == 4:
+		// LY reads 0 for most of the last line, and is compared as such
+		ppu.ly = 0
+	case ppu.line < visibleLines && ppu.dot == oamScanDots:
+		ppu.scanOAM()
+		if ppu.ly == ppu.wy {
+			ppu.windowTriggered = true
+		}
+		ppu.renderer.start()
+		ppu.mode = Drawing
+	case ppu.mode == Drawing:
+		if ppu.renderer.step() {
+			ppu.mode = HBlank
+		}
+	}
+	ppu.updateStat()
+}
+
+// updateStat raises the STAT interrupt on the rising edge of the ORed
+// STAT sources, so a source going high while another one already is
+// does not trigger it again
+func (ppu *PPU) updateStat() {
+	ppu.coincidence = ppu.ly == ppu.lyc
+	line := (ppu.coincidence && ppu.stat&statLYCIRQ != 0) ||
+		(ppu.mode == HBlank && ppu.stat&statHBlankIRQ != 0) ||
+		(ppu.mode == VBlank && ppu.stat&statVBlankIRQ != 0) ||
+		(ppu.mode == OAMScan && ppu.stat&statOAMIRQ != 0) ||
+		// The OAM source also fires as VBlank starts
+		(ppu.line == visibleLines && ppu.dot == 0 && ppu.stat&statOAMIRQ != 0)
+	if line && !ppu.statLine {
+		ppu.irq.Request(interrupts.LCDStat)
+	}
+	ppu.statLine = line
+}
+
+// endFrame presents the frame drawn and starts a new one
+func (ppu *PPU) endFrame() {
+	ppu.front, ppu.back = ppu.back, ppu.front
+	ppu.windowTriggered = false
+	ppu.windowLine = 0
+}
+
+// setEnabled turns the LCD on or off. While off LY stays at 0 in HBlank
+// and the screen is blank, and once back on the first line starts without
+// an OAM scan.
+func (ppu *PPU) setEnabled(enabled bool) {
+	ppu.line = 0
+	ppu.ly = 0
+	ppu.dot = 0
+	ppu.mode = HBlank
+	ppu.windowTriggered = false
+	ppu.windowLine = 0
+	if enabled {
+		ppu.updateStat()
+	} else {
+		ppu.statLine = false
+		*ppu.back = Frame{}
+		ppu.endFrame()
+	}
+}
+
+func (ppu *PPU) Read(address uint16) byte {
+	switch address {
+	case LCDCAddr:
+		return ppu.lcdc
+	case STATAddr:
+		value := 0x80 | ppu.stat | byte(ppu.mode)
+		if ppu.coincidence {
+			value |= statCoincidence
+		}
+		return value
+	case SCYAddr:
+		return ppu.scy
+	case SCXAddr:
+		return ppu.scx
+	case LYAddr:
+		return ppu.ly
+	case LYCAddr:
+		return ppu.lyc
+	case BGPAddr:
+		return ppu.bgp
+	case OBP0Addr:
+		return ppu.obp0
+	case OBP1Addr:
+		return ppu.obp1
+	case WYAddr:
+		return ppu.wy
+	case WXAddr:
+		return ppu.wx
+	}
+	return 0xFF
+}
+
+func (ppu *PPU) Write(address uint16, data byte) {
+	switch address {
+	case LCDCAddr:
+		enabled := ppu.Enabled()
+		ppu.lcdc = data
+		if enabled != ppu.Enabled() {
+			ppu.setEnabled(!enabled)
+		}
+	case STATAddr:
+		ppu.stat = data & statWritable
+		if ppu.Enabled() {
+			ppu.updateStat()
+		}
+	case SCYAddr:
+		ppu.scy = data
+	case SCXAddr:
+		ppu.scx = data
+	case LYAddr:
+		// LY is read only
+	case LYCAddr:
+		ppu.lyc = data
+		if ppu.Enabled() {
+			ppu.updateStat()
+		}
+	case BGPAddr:
+		ppu.bgp = data
+	case OBP0Addr:
+		ppu.obp0 = data
+	case OBP1Addr:
+		ppu.obp1 = data
+	case WYAddr:
+		ppu.wy = data
+	case WXAddr:
+		ppu.wx = data
+	}
+}
@@ -0,0 +1,228 @@
+package ppu_test
+
+import (
+	"testing"
+
+	"github.com/gorkaio/gboy/pkg/interrupts"
+	"github.com/gorkaio/gboy/pkg/ppu"
+	"github.com/stretchr/testify/assert"
+)
+
+// videoMemory is the VRAM and OAM the PPU reads from, indexed by address
+type videoMemory [0x10000]byte
+
+func (v *videoMemory) ReadVideo(address uint16) byte {
+	return v[address]
+}
+
+func newPPU() (*ppu.PPU, *interrupts.Controller) {
+	irq := interrupts.New()
+	irq.Write(interrupts.EnableAddr, 0xFF)
+	return ppu.New(irq, &videoMemory{}, ppu.ScanlineRenderer), irq
+}
+
+// tickTo advances a new PPU to the given dot of the given line of the first frame
+func tickTo(p *ppu.PPU, line, dot int) {
+	p.Tick(line*456 + dot)
+}
+
+func TestModeTimings(t *testing.T) {
+	tests := []struct {
+		line int
+		dot  int
+		mode ppu.Mode
+	}{
+		{0, 0, ppu.OAMScan},
+		{0, 79, ppu.OAMScan},
+		{0, 80, ppu.Drawing},
+		{0, 251, ppu.Drawing},
+		{0, 252, ppu.HBlank},
+		{0, 455, ppu.HBlank},
+		{1, 0, ppu.OAMScan},
+		{143, 252, ppu.HBlank},
+		{144, 0, ppu.VBlank},
+		{144, 80, ppu.VBlank},
+		{153, 455, ppu.VBlank},
+		{154, 0, ppu.OAMScan},
+	}
+
+	for _, test := range tests {
+		p, _ := newPPU()
+		tickTo(p, test.line, test.dot)
+		assert.Equal(t, test.mode, p.Mode(), "line %d dot %d", test.line, test.dot)
+		assert.Equal(t, byte(0x80|test.mode), p.Read(ppu.STATAddr)&0x83, "line %d dot %d", test.line, test.dot)
+	}
+}
+
+func TestLYCountsLines(t *testing.T) {
+	tests := []struct {
+		line int
+		dot  int
+		ly   byte
+	}{
+		{0, 0, 0},
+		{0, 455, 0},
+		{1, 0, 1},
+		{144, 0, 144},
+		{152, 455, 152},
+		{153, 3, 153},
+		{153, 4, 0},
+		{154, 0, 0},
+		{155, 0, 1},
+	}
+
+	for _, test := range tests {
+		p, _ := newPPU()
+		tickTo(p, test.line, test.dot)
+		assert.Equal(t, test.ly, p.Read(ppu.LYAddr), "line %d dot %d", test.line, test.dot)
+	}
+}
+
+func TestLYIsReadOnly(t *testing.T) {
+	p, _ := newPPU()
+	tickTo(p, 10, 0)
+	p.Write(ppu.LYAddr, 0x00)
+	assert.Equal(t, byte(10), p.Read(ppu.LYAddr))
+}
+
+func TestVBlankInterruptIsRequestedOncePerFrame(t *testing.T) {
+	p, irq := newPPU()
+	tickTo(p, 143, 455)
+	assert.Equal(t, byte(0x00), irq.Pending())
+
+	p.Tick(1)
+	assert.Equal(t, byte(0x01), irq.Pending())
+
+	irq.Acknowledge(interrupts.VBlank)
+	p.Tick(ppu.DotsPerFrame - 1)
+	assert.Equal(t, byte(0x00), irq.Pending())
+	p.Tick(1)
+	assert.Equal(t, byte(0x01), irq.Pending())
+}
+
+func TestSTATRegister(t *testing.T) {
+	p, _ := newPPU()
+	p.Write(ppu.STATAddr, 0xFF)
+	assert.Equal(t, byte(0xFE), p.Read(ppu.STATAddr), "mode and coincidence bits are read only")
+	p.Write(ppu.STATAddr, 0x00)
+	assert.Equal(t, byte(0x86), p.Read(ppu.STATAddr))
+}
+
+func TestCoincidenceFlag(t *testing.T) {
+	p, _ := newPPU()
+	p.Write(ppu.LYCAddr, 0x05)
+	assert.Equal(t, byte(0x05), p.Read(ppu.LYCAddr))
+	assert.Equal(t, byte(0x00), p.Read(ppu.STATAddr)&0x04)
+
+	tickTo(p, 5, 0)
+	assert.Equal(t, byte(0x04), p.Read(ppu.STATAddr)&0x04)
+	p.Tick(456)
+	assert.Equal(t, byte(0x00), p.Read(ppu.STATAddr)&0x04)
+
+	p.Write(ppu.LYCAddr, 0x06)
+	assert.Equal(t, byte(0x04), p.Read(ppu.STATAddr)&0x04)
+}
+
+func TestSTATInterruptSources(t *testing.T) {
+	tests := []struct {
+		name   string
+		stat   byte
+		lyc    byte
+		line   int
+		dot    int
+		before int
+	}{
+		{"HBlank", 0x08, 0xFF, 0, 252, 1},
+		{"VBlank", 0x10, 0xFF, 144, 0, 1},
+		{"OAM scan", 0x20, 0xFF, 1, 0, 1},
+		{"OAM scan on VBlank start", 0x20, 0xFF, 144, 0, 1},
+		{"LY=LYC", 0x40, 0x20, 32, 0, 1},
+		{"LY=LYC on last line", 0x40, 0x00, 153, 4, 1},
+	}
+
+	for _, test := range tests {
+		p, irq := newPPU()
+		p.Write(ppu.LYCAddr, test.lyc)
+		p.Write(ppu.STATAddr, test.stat)
+		irq.Acknowledge(interrupts.LCDStat)
+		tickTo(p, test.line, test.dot-test.before)
+		irq.Acknowledge(interrupts.LCDStat)
+
+		p.Tick(test.before)
+		assert.Equal(t, byte(0x02), irq.Pending()&0x02, test.name)
+	}
+}
+
+func TestSTATInterruptBlocking(t *testing.T) {
+	p, irq := newPPU()
+	// HBlank on line 0 keeps the STAT line high into the OAM scan of line 1
+	p.Write(ppu.STATAddr, 0x28)
+	tickTo(p, 0, 252)
+	assert.Equal(t, byte(0x02), irq.Pending()&0x02)
+	irq.Acknowledge(interrupts.LCDStat)
+
+	p.Tick(204)
+	assert.Equal(t, byte(0x00), irq.Pending()&0x02)
+
+	// LY=LYC during OAM scan does not trigger it again either
+	p.Write(ppu.LYCAddr, 0x01)
+	p.Write(ppu.STATAddr, 0x68)
+	assert.Equal(t, byte(0x00), irq.Pending()&0x02)
+
+	// but the line going low in between does
+	p.Tick(80)
+	assert.Equal(t, byte(0x00), irq.Pending()&0x02)
+	p.Write(ppu.LYCAddr, 0x02)
+	p.Tick(172)
+	assert.Equal(t, byte(0x02), irq.Pending()&0x02)
+}
+
+func TestEnablingASTATSourceWhileItsConditionHoldsTriggersIt(t *testing.T) {
+	p, irq := newPPU()
+	tickTo(p, 0, 300)
+	p.Write(ppu.STATAddr, 0x08)
+	assert.Equal(t, byte(0x02), irq.Pending()&0x02)
+}
+
+func TestTurningTheLCDOffResetsLYAndMode(t *testing.T) {
+	p, irq := newPPU()
+	p.Write(ppu.STATAddr, 0x78)
+	tickTo(p, 100, 100)
+	irq.Acknowledge(interrupts.LCDStat)
+
+	p.Write(ppu.LCDCAddr, 0x11)
+	assert.False(t, p.Enabled())
+	assert.Equal(t, byte(0x00), p.Read(ppu.LYAddr))
+	assert.Equal(t, ppu.HBlank, p.Mode())
+
+	p.Tick(ppu.DotsPerFrame)
+	assert.Equal(t, byte(0x00), p.Read(ppu.LYAddr))
+	assert.Equal(t, ppu.HBlank, p.Mode())
+	assert.Equal(t, byte(0x00), irq.Pending())
+}
+
+func TestTurningTheLCDOnSkipsTheFirstOAMScan(t *testing.T) {
+	p, _ := newPPU()
+	p.Write(ppu.LCDCAddr, 0x11)
+	p.Write(ppu.LCDCAddr, 0x91)
+	assert.True(t, p.Enabled())
+	assert.Equal(t, ppu.HBlank, p.Mode())
+
+	p.Tick(79)
+	assert.Equal(t, ppu.HBlank, p.Mode())
+	p.Tick(1)
+	assert.Equal(t, ppu.Drawing, p.Mode())
+	p.Tick(172)
+	assert.Equal(t, ppu.HBlank, p.Mode())
+	p.Tick(204)
+	assert.Equal(t, ppu.OAMScan, p.Mode())
+	assert.Equal(t, byte(0x01), p.Read(ppu.LYAddr))
+}
+
+func TestScrollRegisters(t *testing.T) {
+	p, _ := newPPU()
+	p.Write(ppu.SCYAddr, 0x12)
+	p.Write(ppu.SCXAddr, 0x34)
+	assert.Equal(t, byte(0x12), p.Read(ppu.SCYAddr))
+	assert.Equal(t, byte(0x34), p.Read(ppu.SCXAddr))
+}
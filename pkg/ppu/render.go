@@ -0,0 +1,102 @@
+package ppu
+
+// Screen size, in pixels
+const (
+	ScreenWidth  = 160
+	ScreenHeight = 144
+)
+
+// Frame holds the shade of every pixel on screen, from 0 (white) to 3 (black)
+type Frame [ScreenHeight][ScreenWidth]byte
+
+// Tile maps and tile data blocks in VRAM
+const (
+	tileMap0     = 0x9800
+	tileMap1     = 0x9C00
+	tileData8000 = 0x8000
+	tileData8800 = 0x9000
+)
+
+// scanline draws every line at once at the end of mode 3
+type scanline struct {
+	ppu  *PPU
+	dots int
+}
+
+func (r *scanline) start() {
+	r.dots = 0
+}
+
+func (r *scanline) step() bool {
+	r.dots++
+	if r.dots < drawingDots {
+		return false
+	}
+	r.ppu.renderLine()
+	return true
+}
+
+// renderLine draws the background, window and sprites for the current line
+func (ppu *PPU) renderLine() {
+	var colors [ScreenWidth]byte
+	pixels := &ppu.back[ppu.line]
+	if ppu.lcdc&lcdcBGEnable != 0 {
+		ppu.renderBackground(&colors)
+		for x, color := range colors {
+			pixels[x] = ppu.bgp >> (color * 2) & 0x03
+		}
+	} else {
+		// Background and window are blank, regardless of BGP
+		*pixels = [ScreenWidth]byte{}
+	}
+	ppu.renderSprites(pixels, &colors)
+}
+
+// renderBackground fetches the color indexes of the background and window
+func (ppu *PPU) renderBackground(colors *[ScreenWidth]byte) {
+	windowX := int(ppu.wx) - 7
+	window := ppu.windowTriggered && ppu.lcdc&lcdcWindowEnable != 0 && windowX < ScreenWidth
+
+	bgMap := uint16(tileMap0)
+	if ppu.lcdc&lcdcBGTileMap != 0 {
+		bgMap = tileMap1
+	}
+	windowMap := uint16(tileMap0)
+	if ppu.lcdc&lcdcWindowMap != 0 {
+		windowMap = tileMap1
+	}
+
+	y := ppu.ly + ppu.scy
+	for x := range colors {
+		if window && x >= windowX {
+			colors[x] = ppu.tilePixel(windowMap, byte(x-windowX), byte(ppu.windowLine))
+		} else {
+			colors[x] = ppu.tilePixel(bgMap, byte(x)+ppu.scx, y)
+		}
+	}
+
+	// The window keeps its own line counter, which only advances on
+	// lines where it was drawn
+	if window {
+		ppu.windowLine++
+	}
+}
+
+// tilePixel returns the color index of a pixel of the 256x256 tile map
+func (ppu *PPU) tilePixel(tileMap uint16, x, y byte) byte {
+	tile := ppu.video.ReadVideo(tileMap + uint16(y/8)*32 + uint16(x/8))
+	address := ppu.tileAddress(tile) + uint16(y%8)*2
+	low := ppu.video.ReadVideo(address)
+	high := ppu.video.ReadVideo(address + 1)
+	bit := 7 - x%8
+	return (high>>bit&1)<<1 | low>>bit&1
+}
+
+// tileAddress returns the address of background and window tile data,
+// indexed from 0x8000 or signed from 0x9000 depending on LCDC bit 4
+func (ppu *PPU) tileAddress(tile byte) uint16 {
+	if ppu.lcdc&lcdcTileData != 0 {
+		return tileData8000 + uint16(tile)*16
+	}
+	return uint16(tileData8800 + int(int8(tile))*16)
+}
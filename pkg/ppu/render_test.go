@@ -0,0 +1,313 @@
+package ppu_test
+
+import (
+	"testing"
+
+	"github.com/gorkaio/gboy/pkg/interrupts"
+	"github.com/gorkaio/gboy/pkg/ppu"
+	"github.com/stretchr/testify/assert"
+)
+
+var renderers = map[string]ppu.Renderer{
+	"scanline": ppu.ScanlineRenderer,
+	"fifo":     ppu.FIFORenderer,
+}
+
+// forEachRenderer runs a test with every renderer
+func forEachRenderer(t *testing.T, test func(t *testing.T, renderer ppu.Renderer)) {
+	for name, renderer := range renderers {
+		t.Run(name, func(t *testing.T) {
+			test(t, renderer)
+		})
+	}
+}
+
+func newRenderTest(renderer ppu.Renderer) (*ppu.PPU, *videoMemory) {
+	video := &videoMemory{}
+	p := ppu.New(interrupts.New(), video, renderer)
+	// Identity palette, so shades match color indexes
+	p.Write(ppu.BGPAddr, 0xE4)
+	return p, video
+}
+
+// solidTile fills the tile at address with a single color
+func solidTile(video *videoMemory, address uint16, color byte) {
+	for row := uint16(0); row < 8; row++ {
+		if color&1 != 0 {
+			video[address+row*2] = 0xFF
+		}
+		if color&2 != 0 {
+			video[address+row*2+1] = 0xFF
+		}
+	}
+}
+
+// drawFrame runs a new PPU until it presents its first frame
+func drawFrame(p *ppu.PPU) *ppu.Frame {
+	tickTo(p, 144, 0)
+	return p.Frame()
+}
+
+func TestBackgroundTiles(t *testing.T) {
+	forEachRenderer(t, func(t *testing.T, renderer ppu.Renderer) {
+		p, video := newRenderTest(renderer)
+		solidTile(video, 0x8010, 1)
+		solidTile(video, 0x8020, 2)
+		video[0x8030] = 0x80
+		video[0x8031] = 0x01
+		video[0x9800] = 1
+		video[0x9801] = 2
+		video[0x9802] = 3
+		video[0x9820] = 2
+
+		frame := drawFrame(p)
+		assert.Equal(t, byte(1), frame[0][0])
+		assert.Equal(t, byte(1), frame[7][7])
+		assert.Equal(t, byte(2), frame[0][8])
+		assert.Equal(t, byte(1), frame[0][16])
+		assert.Equal(t, byte(0), frame[0][17])
+		assert.Equal(t, byte(2), frame[0][23])
+		assert.Equal(t, byte(0), frame[1][16])
+		assert.Equal(t, byte(2), frame[8][0])
+		assert.Equal(t, byte(0), frame[0][24])
+		assert.Equal(t, byte(0), frame[143][159])
+	})
+}
+
+func TestBackgroundPalette(t *testing.T) {
+	forEachRenderer(t, func(t *testing.T, renderer ppu.Renderer) {
+		tests := []struct {
+			bgp    byte
+			shades [4]byte
+		}{
+			{0xE4, [4]byte{0, 1, 2, 3}},
+			{0x1B, [4]byte{3, 2, 1, 0}},
+			{0xFC, [4]byte{0, 3, 3, 3}},
+			{0x00, [4]byte{0, 0, 0, 0}},
+		}
+
+		for _, test := range tests {
+			p, video := newRenderTest(renderer)
+			p.Write(ppu.BGPAddr, test.bgp)
+			for color := byte(0); color < 4; color++ {
+				solidTile(video, 0x8000+uint16(color)*16, color)
+				video[0x9800+uint16(color)] = color
+			}
+
+			frame := drawFrame(p)
+			for color := byte(0); color < 4; color++ {
+				assert.Equal(t, test.shades[color], frame[0][color*8], "BGP %#02x color %d", test.bgp, color)
+			}
+		}
+	})
+}
+
+func TestBackgroundScrolling(t *testing.T) {
+	forEachRenderer(t, func(t *testing.T, renderer ppu.Renderer) {
+		tests := []struct {
+			scx, scy byte
+			x, y     int
+			shade    byte
+		}{
+			{0, 0, 0, 0, 1},
+			{4, 8, 0, 0, 2},
+			{4, 8, 4, 0, 0},
+			{0, 8, 0, 0, 2},
+			{252, 0, 0, 0, 3},
+			{252, 0, 4, 0, 1},
+			{0, 248, 0, 8, 1},
+			{0, 248, 0, 0, 3},
+		}
+
+		for _, test := range tests {
+			p, video := newRenderTest(renderer)
+			solidTile(video, 0x8010, 1)
+			solidTile(video, 0x8020, 2)
+			solidTile(video, 0x8030, 3)
+			video[0x9800] = 1
+			video[0x9820] = 2
+			video[0x981F] = 3
+			video[0x9BE0] = 3
+			p.Write(ppu.SCXAddr, test.scx)
+			p.Write(ppu.SCYAddr, test.scy)
+
+			frame := drawFrame(p)
+			assert.Equal(t, test.shade, frame[test.y][test.x], "SCX %d SCY %d at %d,%d", test.scx, test.scy, test.x, test.y)
+		}
+	})
+}
+
+func TestSignedTileDataAddressing(t *testing.T) {
+	forEachRenderer(t, func(t *testing.T, renderer ppu.Renderer) {
+		p, video := newRenderTest(renderer)
+		p.Write(ppu.LCDCAddr, 0x81)
+		solidTile(video, 0x8000, 2)
+		solidTile(video, 0x8800, 3)
+		solidTile(video, 0x9000, 1)
+		solidTile(video, 0x97F0, 2)
+		video[0x9800] = 0x80
+		video[0x9801] = 0x00
+		video[0x9802] = 0x7F
+		// Tile 0 is still read from 0x9000 elsewhere in the map
+		video[0x9803] = 0x00
+
+		frame := drawFrame(p)
+		assert.Equal(t, byte(3), frame[0][0])
+		assert.Equal(t, byte(1), frame[0][8])
+		assert.Equal(t, byte(2), frame[0][16])
+		assert.Equal(t, byte(1), frame[0][24])
+	})
+}
+
+func TestBackgroundTileMapSelection(t *testing.T) {
+	forEachRenderer(t, func(t *testing.T, renderer ppu.Renderer) {
+		p, video := newRenderTest(renderer)
+		p.Write(ppu.LCDCAddr, 0x99)
+		solidTile(video, 0x8010, 1)
+		video[0x9800] = 0
+		video[0x9C00] = 1
+
+		frame := drawFrame(p)
+		assert.Equal(t, byte(1), frame[0][0])
+	})
+}
+
+func TestWindow(t *testing.T) {
+	forEachRenderer(t, func(t *testing.T, renderer ppu.Renderer) {
+		tests := []struct {
+			wx, wy byte
+			x, y   int
+			shade  byte
+		}{
+			{87, 72, 80, 72, 1},
+			{87, 72, 79, 72, 0},
+			{87, 72, 80, 71, 0},
+			{87, 72, 159, 143, 1},
+			{7, 0, 0, 0, 1},
+			{166, 0, 159, 0, 1},
+			{166, 0, 158, 0, 0},
+			{167, 0, 159, 0, 0},
+			{7, 144, 0, 143, 0},
+		}
+
+		for _, test := range tests {
+			p, video := newRenderTest(renderer)
+			p.Write(ppu.LCDCAddr, 0xF1)
+			solidTile(video, 0x8010, 1)
+			for address := uint16(0x9C00); address <= 0x9FFF; address++ {
+				video[address] = 1
+			}
+			p.Write(ppu.WXAddr, test.wx)
+			p.Write(ppu.WYAddr, test.wy)
+
+			frame := drawFrame(p)
+			assert.Equal(t, test.shade, frame[test.y][test.x], "WX %d WY %d at %d,%d", test.wx, test.wy, test.x, test.y)
+		}
+	})
+}
+
+func TestWindowIgnoresScrolling(t *testing.T) {
+	forEachRenderer(t, func(t *testing.T, renderer ppu.Renderer) {
+		p, video := newRenderTest(renderer)
+		p.Write(ppu.LCDCAddr, 0xB1)
+		video[0x8010] = 0x80
+		video[0x9800] = 1
+		p.Write(ppu.WXAddr, 7)
+		p.Write(ppu.SCXAddr, 5)
+		p.Write(ppu.SCYAddr, 5)
+
+		frame := drawFrame(p)
+		assert.Equal(t, byte(1), frame[0][0])
+		assert.Equal(t, byte(0), frame[0][1])
+		assert.Equal(t, byte(0), frame[1][0])
+	})
+}
+
+func TestWindowLineCounterOnlyAdvancesWhenDrawn(t *testing.T) {
+	forEachRenderer(t, func(t *testing.T, renderer ppu.Renderer) {
+		p, video := newRenderTest(renderer)
+		p.Write(ppu.LCDCAddr, 0xF1)
+		solidTile(video, 0x8010, 1)
+		solidTile(video, 0x8020, 2)
+		video[0x9C00] = 1
+		video[0x9C20] = 2
+		p.Write(ppu.WXAddr, 7)
+
+		p.Tick(4 * 456)
+		p.Write(ppu.LCDCAddr, 0xD1)
+		p.Tick(16 * 456)
+		p.Write(ppu.LCDCAddr, 0xF1)
+		p.Tick(124 * 456)
+
+		frame := p.Frame()
+		assert.Equal(t, byte(1), frame[3][0])
+		assert.Equal(t, byte(0), frame[4][0])
+		assert.Equal(t, byte(0), frame[19][0])
+		assert.Equal(t, byte(1), frame[20][0])
+		assert.Equal(t, byte(1), frame[23][0])
+		assert.Equal(t, byte(2), frame[24][0])
+	})
+}
+
+func TestWindowStartsOverOnEveryFrame(t *testing.T) {
+	forEachRenderer(t, func(t *testing.T, renderer ppu.Renderer) {
+		p, video := newRenderTest(renderer)
+		p.Write(ppu.LCDCAddr, 0xF1)
+		solidTile(video, 0x8010, 1)
+		video[0x9C00] = 1
+		p.Write(ppu.WXAddr, 7)
+		p.Write(ppu.WYAddr, 0)
+
+		p.Tick(2 * ppu.DotsPerFrame)
+		assert.Equal(t, byte(1), p.Frame()[0][0])
+		assert.Equal(t, byte(0), p.Frame()[8][0])
+	})
+}
+
+func TestDisabledBackgroundIsBlank(t *testing.T) {
+	forEachRenderer(t, func(t *testing.T, renderer ppu.Renderer) {
+		p, video := newRenderTest(renderer)
+		p.Write(ppu.LCDCAddr, 0xB0)
+		p.Write(ppu.BGPAddr, 0xFF)
+		solidTile(video, 0x8000, 3)
+
+		frame := drawFrame(p)
+		assert.Equal(t, byte(0), frame[0][0])
+		assert.Equal(t, byte(0), frame[143][159])
+	})
+}
+
+func TestFrameIsPresentedOnVBlank(t *testing.T) {
+	forEachRenderer(t, func(t *testing.T, renderer ppu.Renderer) {
+		p, video := newRenderTest(renderer)
+		solidTile(video, 0x8000, 3)
+		tickTo(p, 143, 455)
+		assert.Equal(t, byte(0), p.Frame()[0][0])
+		p.Tick(1)
+		assert.Equal(t, byte(3), p.Frame()[0][0])
+	})
+}
+
+func TestTurningTheLCDOffBlanksTheScreen(t *testing.T) {
+	forEachRenderer(t, func(t *testing.T, renderer ppu.Renderer) {
+		p, video := newRenderTest(renderer)
+		solidTile(video, 0x8000, 3)
+		frame := drawFrame(p)
+		assert.Equal(t, byte(3), frame[0][0])
+
+		p.Write(ppu.LCDCAddr, 0x11)
+		assert.Equal(t, byte(0), p.Frame()[0][0])
+		assert.Equal(t, byte(0), p.Frame()[143][159])
+	})
+}
+
+func TestPaletteAndWindowRegisters(t *testing.T) {
+	forEachRenderer(t, func(t *testing.T, renderer ppu.Renderer) {
+		p, _ := newRenderTest(renderer)
+		assert.Equal(t, byte(0xE4), p.Read(ppu.BGPAddr))
+		p.Write(ppu.WYAddr, 0x12)
+		p.Write(ppu.WXAddr, 0x34)
+		assert.Equal(t, byte(0x12), p.Read(ppu.WYAddr))
+		assert.Equal(t, byte(0x34), p.Read(ppu.WXAddr))
+	})
+}
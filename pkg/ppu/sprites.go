@@ -0,0 +1,105 @@
+package ppu
+
+import (
+	"sort"
+)
+
+const (
+	oamAddress        = 0xFE00
+	oamSprites        = 40
+	maxSpritesPerLine = 10
+)
+
+// Sprite attribute bits
+const (
+	attrPalette    = 0x10
+	attrXFlip      = 0x20
+	attrYFlip      = 0x40
+	attrBGPriority = 0x80
+)
+
+// sprite is an object selected during OAM scan to be drawn on the line
+type sprite struct {
+	x          int
+	address    uint16
+	attributes byte
+}
+
+// scanOAM selects the first ten sprites in OAM overlapping the current line
+func (ppu *PPU) scanOAM() {
+	height := 8
+	if ppu.lcdc&lcdcOBJSize != 0 {
+		height = 16
+	}
+
+	ppu.sprites = ppu.sprites[:0]
+	for i := 0; i < oamSprites && len(ppu.sprites) < maxSpritesPerLine; i++ {
+		address := uint16(oamAddress + i*4)
+		row := ppu.line - (int(ppu.video.ReadVideo(address)) - 16)
+		if row < 0 || row >= height {
+			continue
+		}
+
+		tile := ppu.video.ReadVideo(address + 2)
+		attributes := ppu.video.ReadVideo(address + 3)
+		if attributes&attrYFlip != 0 {
+			row = height - 1 - row
+		}
+		if height == 16 {
+			// 8x16 sprites are made of an even tile and the one following it
+			tile &^= 1
+		}
+		ppu.sprites = append(ppu.sprites, sprite{
+			x:          int(ppu.video.ReadVideo(address+1)) - 8,
+			address:    tileData8000 + uint16(tile)*16 + uint16(row)*2,
+			attributes: attributes,
+		})
+	}
+
+	// On DMG the sprite with the lowest X wins, then the one first in OAM
+	sort.SliceStable(ppu.sprites, func(i, j int) bool {
+		return ppu.sprites[i].x < ppu.sprites[j].x
+	})
+}
+
+// renderSprites draws the sprites selected for the current line over the
+// background, given the color indexes it was drawn with
+func (ppu *PPU) renderSprites(pixels *[ScreenWidth]byte, bg *[ScreenWidth]byte) {
+	if ppu.lcdc&lcdcOBJEnable == 0 {
+		return
+	}
+
+	// Once a sprite has an opaque pixel, lower priority sprites are hidden
+	// there even if the background is drawn over it
+	var drawn [ScreenWidth]bool
+	for _, s := range ppu.sprites {
+		low := ppu.video.ReadVideo(s.address)
+		high := ppu.video.ReadVideo(s.address + 1)
+		palette := ppu.obp0
+		if s.attributes&attrPalette != 0 {
+			palette = ppu.obp1
+		}
+
+		for i := 0; i < 8; i++ {
+			x := s.x + i
+			if x < 0 || x >= ScreenWidth || drawn[x] {
+				continue
+			}
+			bit := byte(7 - i)
+			if s.attributes&attrXFlip != 0 {
+				bit = byte(i)
+			}
+			color := (high>>bit&1)<<1 | low>>bit&1
+			if color == 0 {
+				// Color 0 is transparent
+				continue
+			}
+
+			drawn[x] = true
+			if s.attributes&attrBGPriority != 0 && bg[x] != 0 {
+				continue
+			}
+			pixels[x] = palette >> (color * 2) & 0x03
+		}
+	}
+}
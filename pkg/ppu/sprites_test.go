@@ -0,0 +1,279 @@
+package ppu_test
+
+import (
+	"testing"
+
+	"github.com/gorkaio/gboy/pkg/ppu"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSpriteTest(renderer ppu.Renderer) (*ppu.PPU, *videoMemory) {
+	p, video := newRenderTest(renderer)
+	p.Write(ppu.LCDCAddr, 0x93)
+	p.Write(ppu.OBP0Addr, 0xE4)
+	p.Write(ppu.OBP1Addr, 0x1B)
+	return p, video
+}
+
+// setSprite writes a sprite to OAM, at screen coordinates x and y
+func setSprite(video *videoMemory, index int, x, y int, tile, attributes byte) {
+	address := 0xFE00 + index*4
+	video[address] = byte(y + 16)
+	video[address+1] = byte(x + 8)
+	video[address+2] = tile
+	video[address+3] = attributes
+}
+
+func TestSpritesAreDrawnWithTheirPalette(t *testing.T) {
+	forEachRenderer(t, func(t *testing.T, renderer ppu.Renderer) {
+		p, video := newSpriteTest(renderer)
+		solidTile(video, 0x8010, 1)
+		setSprite(video, 0, 10, 20, 1, 0x00)
+		setSprite(video, 1, 30, 20, 1, 0x10)
+
+		frame := drawFrame(p)
+		assert.Equal(t, byte(0), frame[20][9])
+		assert.Equal(t, byte(1), frame[20][10])
+		assert.Equal(t, byte(1), frame[27][17])
+		assert.Equal(t, byte(0), frame[28][10])
+		assert.Equal(t, byte(0), frame[20][18])
+		assert.Equal(t, byte(2), frame[20][30], "OBP1")
+	})
+}
+
+func TestSpriteColorZeroIsTransparent(t *testing.T) {
+	forEachRenderer(t, func(t *testing.T, renderer ppu.Renderer) {
+		p, video := newSpriteTest(renderer)
+		solidTile(video, 0x8000, 2)
+		setSprite(video, 0, 0, 0, 1, 0x00)
+
+		frame := drawFrame(p)
+		assert.Equal(t, byte(2), frame[0][0])
+	})
+}
+
+func TestSpriteFlips(t *testing.T) {
+	forEachRenderer(t, func(t *testing.T, renderer ppu.Renderer) {
+		tests := []struct {
+			attributes byte
+			x, y       int
+		}{
+			{0x00, 0, 0},
+			{0x20, 7, 0},
+			{0x40, 0, 7},
+			{0x60, 7, 7},
+		}
+
+		for _, test := range tests {
+			p, video := newSpriteTest(renderer)
+			// A single pixel at the top left corner of the tile
+			video[0x8010] = 0x80
+			setSprite(video, 0, 8, 8, 1, test.attributes)
+
+			frame := drawFrame(p)
+			for y := 0; y < 8; y++ {
+				for x := 0; x < 8; x++ {
+					shade := byte(0)
+					if x == test.x && y == test.y {
+						shade = 1
+					}
+					assert.Equal(t, shade, frame[8+y][8+x], "Attributes %#02x at %d,%d", test.attributes, x, y)
+				}
+			}
+		}
+	})
+}
+
+func TestTallSpritesIgnoreTheTileLowBit(t *testing.T) {
+	forEachRenderer(t, func(t *testing.T, renderer ppu.Renderer) {
+		tests := []struct {
+			tile       byte
+			attributes byte
+			top        byte
+			bottom     byte
+		}{
+			{2, 0x00, 1, 2},
+			{3, 0x00, 1, 2},
+			{3, 0x40, 2, 1},
+		}
+
+		for _, test := range tests {
+			p, video := newSpriteTest(renderer)
+			p.Write(ppu.LCDCAddr, 0x97)
+			solidTile(video, 0x8020, 1)
+			solidTile(video, 0x8030, 2)
+			setSprite(video, 0, 0, 0, test.tile, test.attributes)
+
+			frame := drawFrame(p)
+			assert.Equal(t, test.top, frame[0][0], "Tile %d attributes %#02x", test.tile, test.attributes)
+			assert.Equal(t, test.top, frame[7][0], "Tile %d attributes %#02x", test.tile, test.attributes)
+			assert.Equal(t, test.bottom, frame[8][0], "Tile %d attributes %#02x", test.tile, test.attributes)
+			assert.Equal(t, test.bottom, frame[15][0], "Tile %d attributes %#02x", test.tile, test.attributes)
+			assert.Equal(t, byte(0), frame[16][0], "Tile %d attributes %#02x", test.tile, test.attributes)
+		}
+	})
+}
+
+func TestTenSpritesPerLine(t *testing.T) {
+	forEachRenderer(t, func(t *testing.T, renderer ppu.Renderer) {
+		p, video := newSpriteTest(renderer)
+		solidTile(video, 0x8010, 1)
+		for i := 0; i < 11; i++ {
+			setSprite(video, i, 150-i*8, 0, 1, 0x00)
+		}
+		// Sprites on other lines don't count
+		setSprite(video, 11, 0, 8, 1, 0x00)
+
+		frame := drawFrame(p)
+		assert.Equal(t, byte(1), frame[0][150-9*8])
+		assert.Equal(t, byte(0), frame[0][150-10*8], "The eleventh sprite is not drawn")
+		assert.Equal(t, byte(1), frame[8][0])
+	})
+}
+
+func TestHiddenSpritesCountTowardsTheLimit(t *testing.T) {
+	forEachRenderer(t, func(t *testing.T, renderer ppu.Renderer) {
+		p, video := newSpriteTest(renderer)
+		solidTile(video, 0x8010, 1)
+		for i := 0; i < 10; i++ {
+			setSprite(video, i, -8, 0, 1, 0x00)
+		}
+		setSprite(video, 10, 0, 0, 1, 0x00)
+
+		frame := drawFrame(p)
+		assert.Equal(t, byte(0), frame[0][0])
+	})
+}
+
+func TestSpritePriority(t *testing.T) {
+	forEachRenderer(t, func(t *testing.T, renderer ppu.Renderer) {
+		tests := []struct {
+			name   string
+			x0, x1 int
+			shade  byte
+		}{
+			{"Lower X wins", 4, 0, 2},
+			{"Lower X wins regardless of OAM order", 0, 4, 1},
+			{"Same X, first in OAM wins", 0, 0, 1},
+		}
+
+		for _, test := range tests {
+			p, video := newSpriteTest(renderer)
+			solidTile(video, 0x8010, 1)
+			solidTile(video, 0x8020, 2)
+			setSprite(video, 0, test.x0, 0, 1, 0x00)
+			setSprite(video, 1, test.x1, 0, 2, 0x00)
+
+			frame := drawFrame(p)
+			assert.Equal(t, test.shade, frame[0][4], test.name)
+		}
+	})
+}
+
+func TestTransparentPixelsShowLowerPrioritySprites(t *testing.T) {
+	forEachRenderer(t, func(t *testing.T, renderer ppu.Renderer) {
+		p, video := newSpriteTest(renderer)
+		// Left half opaque
+		for row := uint16(0); row < 8; row++ {
+			video[0x8010+row*2] = 0xF0
+		}
+		solidTile(video, 0x8020, 2)
+		setSprite(video, 0, 0, 0, 1, 0x00)
+		setSprite(video, 1, 0, 0, 2, 0x00)
+
+		frame := drawFrame(p)
+		assert.Equal(t, byte(1), frame[0][3])
+		assert.Equal(t, byte(2), frame[0][4])
+	})
+}
+
+func TestBackgroundOverSprites(t *testing.T) {
+	forEachRenderer(t, func(t *testing.T, renderer ppu.Renderer) {
+		tests := []struct {
+			name       string
+			bg         byte
+			attributes byte
+			shade      byte
+		}{
+			{"Sprite over background", 2, 0x00, 1},
+			{"Background over sprite", 2, 0x80, 2},
+			{"Sprite over background color 0", 0, 0x80, 1},
+		}
+
+		for _, test := range tests {
+			p, video := newSpriteTest(renderer)
+			solidTile(video, 0x8000, test.bg)
+			solidTile(video, 0x8010, 1)
+			setSprite(video, 0, 0, 0, 1, test.attributes)
+
+			frame := drawFrame(p)
+			assert.Equal(t, test.shade, frame[0][0], test.name)
+		}
+	})
+}
+
+func TestBackgroundPriorityUsesColorIndexNotShade(t *testing.T) {
+	forEachRenderer(t, func(t *testing.T, renderer ppu.Renderer) {
+		p, video := newSpriteTest(renderer)
+		// Color 1 shows as white, but still hides the sprite
+		p.Write(ppu.BGPAddr, 0xE0)
+		solidTile(video, 0x8000, 1)
+		solidTile(video, 0x8010, 3)
+		setSprite(video, 0, 0, 0, 1, 0x80)
+
+		frame := drawFrame(p)
+		assert.Equal(t, byte(0), frame[0][0])
+	})
+}
+
+func TestHiddenSpritesStillHideLowerPriorityOnes(t *testing.T) {
+	forEachRenderer(t, func(t *testing.T, renderer ppu.Renderer) {
+		p, video := newSpriteTest(renderer)
+		solidTile(video, 0x8000, 2)
+		solidTile(video, 0x8010, 1)
+		solidTile(video, 0x8020, 3)
+		setSprite(video, 0, 0, 0, 1, 0x80)
+		setSprite(video, 1, 0, 0, 2, 0x00)
+
+		frame := drawFrame(p)
+		assert.Equal(t, byte(2), frame[0][0])
+	})
+}
+
+func TestSpritesCanBeDisabled(t *testing.T) {
+	forEachRenderer(t, func(t *testing.T, renderer ppu.Renderer) {
+		p, video := newSpriteTest(renderer)
+		p.Write(ppu.LCDCAddr, 0x91)
+		solidTile(video, 0x8010, 1)
+		setSprite(video, 0, 0, 0, 1, 0x00)
+
+		frame := drawFrame(p)
+		assert.Equal(t, byte(0), frame[0][0])
+	})
+}
+
+func TestSpritesAreDrawnOverADisabledBackground(t *testing.T) {
+	forEachRenderer(t, func(t *testing.T, renderer ppu.Renderer) {
+		p, video := newSpriteTest(renderer)
+		p.Write(ppu.LCDCAddr, 0x92)
+		solidTile(video, 0x8000, 3)
+		solidTile(video, 0x8010, 1)
+		setSprite(video, 0, 0, 0, 1, 0x80)
+
+		frame := drawFrame(p)
+		assert.Equal(t, byte(1), frame[0][0])
+		assert.Equal(t, byte(0), frame[0][8])
+	})
+}
+
+func TestSpritePaletteRegisters(t *testing.T) {
+	forEachRenderer(t, func(t *testing.T, renderer ppu.Renderer) {
+		p, _ := newRenderTest(renderer)
+		assert.Equal(t, byte(0xFF), p.Read(ppu.OBP0Addr))
+		assert.Equal(t, byte(0xFF), p.Read(ppu.OBP1Addr))
+		p.Write(ppu.OBP0Addr, 0x12)
+		p.Write(ppu.OBP1Addr, 0x34)
+		assert.Equal(t, byte(0x12), p.Read(ppu.OBP0Addr))
+		assert.Equal(t, byte(0x34), p.Read(ppu.OBP1Addr))
+	})
+}